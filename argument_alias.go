@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// aliasNames splits an alias or envAlias tag by "," and adds the prefix of the parent
+// structs to every name.
+func aliasNames(prefix string, tag string) []string {
+	var result []string
+	for _, name := range strings.Split(tag, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		result = append(result, prefix+name)
+	}
+	return result
+}
+
+// argNames returns the flag name of the field followed by its aliases.
+func (f field) argNames() []string {
+	return append([]string{f.argName}, f.argAliases...)
+}
+
+// envNames returns the env name of the field followed by its env aliases.
+func (f field) envNames() []string {
+	return append([]string{f.envName}, f.envAliases...)
+}
+
+// visitedArgName returns the flag name that supplies the value of the field.
+// The flag name of the field wins over its aliases, earlier aliases win over later ones.
+func (f field) visitedArgName(visited map[string]bool) (string, bool) {
+	for _, name := range f.argNames() {
+		if visited[name] {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// isDeprecatedName reports whether using name for the field is deprecated.
+// The deprecated tag applies to the aliases of a field, or to the field itself if it has
+// no aliases.
+func (f field) isDeprecatedName(name string, primary string) bool {
+	if !f.hasDeprecated {
+		return false
+	}
+	if len(f.argAliases) == 0 && len(f.envAliases) == 0 {
+		return true
+	}
+	return name != primary
+}
+
+// warnAliases logs a warning if the value of the field was supplied by a deprecated name and
+// for every other name of the field that is ignored because name was given as well.
+// kind is "parameter" or "env", names lists all names of that kind that were given.
+func warnAliases(
+	ctx context.Context,
+	logger *slog.Logger,
+	f field,
+	kind string,
+	primary string,
+	name string,
+	names []string,
+) {
+	if f.isDeprecatedName(name, primary) {
+		message := fmt.Sprintf("%s %s is deprecated", kind, name)
+		if f.deprecated != "" {
+			message += ", " + f.deprecated
+		}
+		logger.WarnContext(ctx, message, "field", f.path)
+	}
+	for _, ignored := range names {
+		if ignored == name {
+			continue
+		}
+		logger.WarnContext(
+			ctx,
+			fmt.Sprintf("%s %s is ignored, because %s %s is set", kind, ignored, kind, name),
+			"field",
+			f.path,
+		)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("Aliases", func() {
+	type kafka struct {
+		Brokers []string `arg:"brokers" env:"BROKERS" alias:"broker" envAlias:"BROKER" deprecated:"use -kafka-brokers"`
+	}
+	type config struct {
+		Kafka   kafka  `arg:"kafka-"  env:"KAFKA_"`
+		Host    string `arg:"host"    env:"HOST"   alias:"h"`
+		Verbose bool   `arg:"verbose"                        deprecated:"use -log-level"`
+	}
+	var ctx context.Context
+	var logs *bytes.Buffer
+	var cfg config
+	parse := func(args []string, environ []string) (argument.Provenance, error) {
+		return newTestParser(
+			args,
+			environ,
+			argument.WithLogger(slog.New(slog.NewTextHandler(logs, nil))),
+		).ParseWithReport(ctx, &cfg)
+	}
+	BeforeEach(func() {
+		ctx = context.Background()
+		logs = &bytes.Buffer{}
+		cfg = config{}
+	})
+	It("accepts aliases without warning", func() {
+		provenance, err := parse([]string{"-h=localhost"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Host).To(Equal("localhost"))
+		Expect(provenance["Host"].Name).To(Equal("h"))
+		Expect(logs.String()).To(BeEmpty())
+	})
+	It("accepts deprecated flag aliases with a warning", func() {
+		provenance, err := parse([]string{"-kafka-broker=a:9092,b:9092"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Kafka.Brokers).To(Equal([]string{"a:9092", "b:9092"}))
+		Expect(provenance["Kafka.Brokers"]).To(Equal(argument.Source{
+			Kind: argument.SourceKindFlag,
+			Name: "kafka-broker",
+			Raw:  "a:9092,b:9092",
+		}))
+		Expect(logs.String()).To(ContainSubstring(
+			`level=WARN msg="parameter kafka-broker is deprecated, use -kafka-brokers" field=Kafka.Brokers`,
+		))
+	})
+	It("accepts deprecated env aliases with a warning", func() {
+		_, err := parse(nil, []string{"KAFKA_BROKER=a:9092"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Kafka.Brokers).To(Equal([]string{"a:9092"}))
+		Expect(logs.String()).To(ContainSubstring(
+			`msg="env KAFKA_BROKER is deprecated, use -kafka-brokers"`,
+		))
+	})
+	It("does not warn for the new names", func() {
+		_, err := parse([]string{"-kafka-brokers=a:9092"}, []string{"KAFKA_BROKERS=b:9092"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Kafka.Brokers).To(Equal([]string{"a:9092"}))
+		Expect(logs.String()).To(BeEmpty())
+	})
+	It("prefers the new name if both are given", func() {
+		_, err := parse(
+			[]string{"-kafka-brokers=new:9092", "-kafka-broker=old:9092"},
+			[]string{"KAFKA_BROKER=old:9092", "KAFKA_BROKERS=new:9092"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Kafka.Brokers).To(Equal([]string{"new:9092"}))
+		Expect(logs.String()).To(ContainSubstring(
+			`msg="parameter kafka-broker is ignored, because parameter kafka-brokers is set"`,
+		))
+		Expect(logs.String()).To(ContainSubstring(
+			`msg="env KAFKA_BROKER is ignored, because env KAFKA_BROKERS is set"`,
+		))
+		Expect(logs.String()).NotTo(ContainSubstring("is deprecated"))
+	})
+	It("prefers an alias flag over the env var", func() {
+		_, err := parse([]string{"-h=flag.example.com"}, []string{"HOST=env.example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Host).To(Equal("flag.example.com"))
+	})
+	It("warns about deprecated fields without aliases", func() {
+		_, err := parse([]string{"-verbose"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Verbose).To(BeTrue())
+		Expect(logs.String()).To(ContainSubstring(
+			`msg="parameter verbose is deprecated, use -log-level" field=Verbose`,
+		))
+	})
+	It("returns error for an alias used by another field", func() {
+		var clash struct {
+			Host   string `arg:"host"   alias:"server"`
+			Server string `arg:"server"`
+		}
+		err := newTestParser(nil, nil).Parse(ctx, &clash)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"flag server of field Server is already used by field Host",
+		))
+	})
+	It("supports aliases with ParseArgs and ParseEnv", func() {
+		parser := argument.NewParser(
+			argument.WithOutput(&bytes.Buffer{}),
+			argument.WithLogger(slog.New(slog.NewTextHandler(logs, nil))),
+		)
+		Expect(parser.ParseArgs(ctx, &cfg, []string{"-h=args.example.com"})).To(Succeed())
+		Expect(cfg.Host).To(Equal("args.example.com"))
+		Expect(parser.ParseEnv(ctx, &cfg, []string{"KAFKA_BROKER=env:9092"})).To(Succeed())
+		Expect(cfg.Kafka.Brokers).To(Equal([]string{"env:9092"}))
+	})
+})
@@ -6,15 +6,12 @@ package argument
 
 import (
 	"context"
-	"encoding"
 	"flag"
+	"log/slog"
 	"reflect"
-	"strconv"
 	"strings"
-	"time"
 
 	"github.com/bborbe/errors"
-	libtime "github.com/bborbe/time"
 )
 
 // ParseArgs parses command-line arguments into the given struct using arg struct tags.
@@ -27,562 +24,332 @@ import (
 //
 // Returns error if parsing fails or if default values are malformed.
 func ParseArgs(ctx context.Context, data interface{}, args []string) error {
-	values, err := argsToValues(ctx, data, args)
-	if err != nil {
-		return errors.Wrap(ctx, err, "args to values failed")
-	}
-	if err := Fill(ctx, data, values); err != nil {
-		return errors.Wrap(ctx, err, "fill failed")
-	}
-	return nil
-}
-
-func handleCustomType(
-	ctx context.Context,
-	values map[string]interface{},
-	tf reflect.StructField,
-	ef reflect.Value,
-	argName, defaultString string,
-	found bool,
-	usage string,
-) (bool, error) {
-	// Get the underlying type
-	underlyingType := ef.Type()
-	for underlyingType.Kind() == reflect.Pointer {
-		underlyingType = underlyingType.Elem()
-	}
-
-	// Check if it's a named type (custom type) with an underlying primitive type
-	if underlyingType.PkgPath() != "" && underlyingType.Kind() != reflect.Struct {
-		switch underlyingType.Kind() {
-		case reflect.String:
-			values[tf.Name] = flag.CommandLine.String(argName, defaultString, usage)
-			return true, nil
-		case reflect.Bool:
-			defaultValue, _ := strconv.ParseBool(defaultString)
-			values[tf.Name] = flag.CommandLine.Bool(argName, defaultValue, usage)
-			return true, nil
-		case reflect.Int:
-			defaultValue, _ := strconv.Atoi(defaultString)
-			values[tf.Name] = flag.CommandLine.Int(argName, defaultValue, usage)
-			return true, nil
-		case reflect.Int64:
-			defaultValue, _ := strconv.ParseInt(defaultString, 10, 0)
-			values[tf.Name] = flag.CommandLine.Int64(argName, defaultValue, usage)
-			return true, nil
-		case reflect.Uint:
-			defaultValue, _ := strconv.ParseUint(defaultString, 10, 0)
-			values[tf.Name] = flag.CommandLine.Uint(argName, uint(defaultValue), usage)
-			return true, nil
-		case reflect.Uint64:
-			defaultValue, _ := strconv.ParseUint(defaultString, 10, 0)
-			values[tf.Name] = flag.CommandLine.Uint64(argName, defaultValue, usage)
-			return true, nil
-		case reflect.Int32:
-			defaultValue, _ := strconv.ParseInt(defaultString, 10, 0)
-			values[tf.Name] = flag.CommandLine.Int(argName, int(defaultValue), usage)
-			return true, nil
-		case reflect.Float64:
-			defaultValue, _ := strconv.ParseFloat(defaultString, 64)
-			values[tf.Name] = flag.CommandLine.Float64(argName, defaultValue, usage)
-			return true, nil
-		}
-	}
-	return false, nil
+	return defaultParser.ParseArgs(ctx, data, args)
 }
 
 // parseSliceFromString splits a string by separator, trims whitespace from each element,
-// and converts to the appropriate slice type based on the element type.
+// and converts each element into elemType. Pointer element types like *url.URL are supported.
 func parseSliceFromString(
 	ctx context.Context,
 	value string,
 	separator string,
 	elemType reflect.Type,
 ) (interface{}, error) {
-	if value == "" {
-		// Return empty slice of the appropriate type
-		return reflect.MakeSlice(reflect.SliceOf(elemType), 0, 0).Interface(), nil
+	if !isElemType(elemType) {
+		return nil, errors.Errorf(ctx, "unsupported slice element type: %v", elemType)
 	}
-
-	parts := strings.Split(value, separator)
-	// Trim whitespace from each part
-	trimmed := make([]string, 0, len(parts))
-	for _, p := range parts {
-		t := strings.TrimSpace(p)
-		if t != "" { // Skip empty parts after trimming
-			trimmed = append(trimmed, t)
+	result := reflect.MakeSlice(reflect.SliceOf(convertedType(elemType)), 0, 0)
+	for _, part := range strings.Split(value, separator) {
+		part = strings.TrimSpace(part)
+		if part == "" { // Skip empty parts after trimming
+			continue
 		}
+		elem, err := convertElem(ctx, part, elemType)
+		if err != nil {
+			return nil, err
+		}
+		result = reflect.Append(result, elem)
 	}
+	return result.Interface(), nil
+}
 
-	// If all parts were empty after trimming, return empty slice
-	if len(trimmed) == 0 {
-		return reflect.MakeSlice(reflect.SliceOf(elemType), 0, 0).Interface(), nil
+// sliceSeparator returns the separator tag of a slice field. Defaults to ",".
+func sliceSeparator(tf reflect.StructField) string {
+	separator := tf.Tag.Get("separator")
+	if separator == "" {
+		return ","
 	}
+	return separator
+}
 
-	// Check if element type implements encoding.TextUnmarshaler
-	ptrType := reflect.PointerTo(elemType)
-	if ptrType.Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
-		result := reflect.MakeSlice(reflect.SliceOf(elemType), len(trimmed), len(trimmed))
-		for i, p := range trimmed {
-			elem := result.Index(i).Addr().Interface().(encoding.TextUnmarshaler)
-			if err := elem.UnmarshalText([]byte(p)); err != nil {
-				return nil, errors.Wrapf(ctx, err, "unmarshal text %q failed", p)
-			}
+func argsToValues(
+	ctx context.Context,
+	flagSet *flag.FlagSet,
+	data interface{},
+	args []string,
+	secrets secretResolvers,
+	logger *slog.Logger,
+	gnu bool,
+	options fieldOptions,
+) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	// aliasValues holds the values parsed from every alias flag by flag name
+	aliasValues := make(map[string]map[string]interface{})
+	if err := walkFieldsWith(data, options, func(f field) error {
+		if !f.hasArg {
+			return nil
 		}
-		return result.Interface(), nil
+		return registerArg(ctx, flagSet, values, aliasValues, f, secrets)
+	}); err != nil {
+		return nil, err
 	}
-
-	// Convert based on element type
-	switch elemType.Kind() {
-	case reflect.String:
-		return trimmed, nil
-	case reflect.Int:
-		result := make([]int, len(trimmed))
-		for i, p := range trimmed {
-			v, err := strconv.Atoi(p)
-			if err != nil {
-				return nil, errors.Wrapf(ctx, err, "parse int %q failed", p)
-			}
-			result[i] = v
+	if err := registerConfigFileArg(ctx, flagSet, data); err != nil {
+		return nil, err
+	}
+	if gnu {
+		shorts, err := shortFlags(ctx, data, options)
+		if err != nil {
+			return nil, err
 		}
-		return result, nil
-	case reflect.Int64:
-		result := make([]int64, len(trimmed))
-		for i, p := range trimmed {
-			v, err := strconv.ParseInt(p, 10, 64)
-			if err != nil {
-				return nil, errors.Wrapf(ctx, err, "parse int64 %q failed", p)
-			}
-			result[i] = v
+		args, err = gnuArgs(ctx, flagSet, shorts, args)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, "parse commandline failed")
 		}
-		return result, nil
-	case reflect.Uint:
-		result := make([]uint, len(trimmed))
-		for i, p := range trimmed {
-			v, err := strconv.ParseUint(p, 10, 0)
-			if err != nil {
-				return nil, errors.Wrapf(ctx, err, "parse uint %q failed", p)
-			}
-			result[i] = uint(v)
+	}
+	if err := flagSet.Parse(args); err != nil {
+		return nil, errors.Wrap(ctx, err, "parse commandline failed")
+	}
+	visited := visitedFlags(flagSet)
+	if err := walkFieldsWith(data, options, func(f field) error {
+		if !f.hasArg {
+			return nil
 		}
-		return result, nil
-	case reflect.Uint64:
-		result := make([]uint64, len(trimmed))
-		for i, p := range trimmed {
-			v, err := strconv.ParseUint(p, 10, 64)
-			if err != nil {
-				return nil, errors.Wrapf(ctx, err, "parse uint64 %q failed", p)
-			}
-			result[i] = v
+		name, ok := f.visitedArgName(visited)
+		if !ok {
+			return nil
 		}
-		return result, nil
-	case reflect.Float64:
-		result := make([]float64, len(trimmed))
-		for i, p := range trimmed {
-			v, err := strconv.ParseFloat(p, 64)
-			if err != nil {
-				return nil, errors.Wrapf(ctx, err, "parse float64 %q failed", p)
-			}
-			result[i] = v
+		if name != f.argName {
+			values[f.path] = aliasValues[name][f.path]
 		}
-		return result, nil
-	case reflect.Bool:
-		result := make([]bool, len(trimmed))
-		for i, p := range trimmed {
-			v, err := strconv.ParseBool(p)
-			if err != nil {
-				return nil, errors.Wrapf(ctx, err, "parse bool %q failed", p)
+		var names []string
+		for _, argName := range f.argNames() {
+			if visited[argName] {
+				names = append(names, argName)
 			}
-			result[i] = v
 		}
-		return result, nil
-	default:
-		// Check for custom string types like Username
-		if elemType.PkgPath() != "" && elemType.Kind() == reflect.String {
-			// Return as []string, Fill() will convert via JSON to custom type
-			return trimmed, nil
+		warnAliases(ctx, logger, f, "parameter", f.argName, name, names)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// visitedFlags returns the names of all flags given in the parsed arguments.
+func visitedFlags(flagSet *flag.FlagSet) map[string]bool {
+	result := make(map[string]bool)
+	flagSet.Visit(func(f *flag.Flag) {
+		result[f.Name] = true
+	})
+	return result
+}
+
+// registerArg registers a flag for the given field. Parsed values are stored in values under the field path.
+// The default is stored as well, so values holds the default for flags not given in args.
+// Defaults of secret fields are left to DefaultValues, so they are not resolved twice.
+// Every alias of the field gets its own flag, its values are stored in aliasValues under the alias.
+func registerArg(
+	ctx context.Context,
+	flagSet *flag.FlagSet,
+	values map[string]interface{},
+	aliasValues map[string]map[string]interface{},
+	f field,
+	secrets secretResolvers,
+) error {
+	if !isSupported(f) {
+		return errors.Errorf(
+			ctx,
+			"field %s with type %T is unsupported",
+			f.path,
+			f.value.Interface(),
+		)
+	}
+	defaultString, found := f.structField.Tag.Lookup("default")
+	if found && defaultString != "" && !f.secret {
+		value, err := convertField(ctx, f, defaultString)
+		if err != nil {
+			return errors.Wrapf(
+				ctx,
+				err,
+				"invalid default value %q for field %s",
+				defaultString,
+				f.path,
+			)
+		}
+		values[f.path] = value
+	}
+	if err := defineFlag(ctx, flagSet, f.argName, f, values, secrets); err != nil {
+		return err
+	}
+	for _, alias := range f.argAliases {
+		aliasValues[alias] = make(map[string]interface{})
+		if err := defineFlag(ctx, flagSet, alias, f, aliasValues[alias], secrets); err != nil {
+			return err
 		}
-		return nil, errors.Errorf(ctx, "unsupported slice element type: %v", elemType)
 	}
+	return nil
 }
 
-//nolint:gocyclo // TODO: Refactor to reduce complexity (currently 101, limit is 30)
-func argsToValues(
+// defineFlag registers the flag name for the field. Instead of the "flag redefined" panic of
+// the flag package, it returns an error if the name is already defined, e.g. by two fields
+// with the same derived name.
+func defineFlag(
 	ctx context.Context,
-	data interface{},
-	args []string,
-) (map[string]interface{}, error) {
-	e := reflect.ValueOf(data).Elem()
-	t := e.Type()
-	values := make(map[string]interface{})
-	for i := 0; i < e.NumField(); i++ {
-		tf := t.Field(i)
-		ef := e.Field(i)
-		argName, ok := tf.Tag.Lookup("arg")
-		if !ok {
-			continue
+	flagSet *flag.FlagSet,
+	name string,
+	f field,
+	values map[string]interface{},
+	secrets secretResolvers,
+) error {
+	if existing := flagSet.Lookup(name); existing != nil {
+		if other, ok := existing.Value.(*fieldFlag); ok {
+			return errors.Errorf(
+				ctx,
+				"flag %s of field %s is already used by field %s",
+				name,
+				f.path,
+				other.path,
+			)
 		}
-		defaultString, found := tf.Tag.Lookup("default")
-		usage := tf.Tag.Get("usage")
-		switch ef.Interface().(type) {
-		case string:
-			values[tf.Name] = flag.CommandLine.String(argName, defaultString, usage)
-		case bool:
-			defaultValue, _ := strconv.ParseBool(defaultString)
-			values[tf.Name] = flag.CommandLine.Bool(argName, defaultValue, usage)
-		case int:
-			defaultValue, _ := strconv.Atoi(defaultString)
-			values[tf.Name] = flag.CommandLine.Int(argName, defaultValue, usage)
-		case int64:
-			defaultValue, _ := strconv.ParseInt(defaultString, 10, 0)
-			values[tf.Name] = flag.CommandLine.Int64(argName, defaultValue, usage)
-		case uint:
-			defaultValue, _ := strconv.ParseUint(defaultString, 10, 0)
-			values[tf.Name] = flag.CommandLine.Uint(argName, uint(defaultValue), usage)
-		case uint64:
-			defaultValue, _ := strconv.ParseUint(defaultString, 10, 0)
-			values[tf.Name] = flag.CommandLine.Uint64(argName, defaultValue, usage)
-		case int32:
-			defaultValue, _ := strconv.ParseInt(defaultString, 10, 0)
-			values[tf.Name] = flag.CommandLine.Int(argName, int(defaultValue), usage)
-		case float64:
-			defaultValue, _ := strconv.ParseFloat(defaultString, 64)
-			values[tf.Name] = flag.CommandLine.Float64(argName, defaultValue, usage)
-		case *float64:
-			if found {
-				defaultValue, _ := strconv.ParseFloat(defaultString, 64)
-				values[tf.Name] = defaultValue
-			}
-			flag.CommandLine.Func(argName, usage, func(s string) error {
-				if s == "" {
-					return nil
-				}
-				v, err := strconv.ParseFloat(s, 64)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse float failed")
-				}
-				values[tf.Name] = v
-				return nil
-			})
-		case time.Duration:
-			if found {
-				defaultValue, _ := libtime.ParseDuration(ctx, defaultString)
-				if defaultValue != nil {
-					values[tf.Name] = defaultValue.Duration()
-				}
-			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				duration, err := libtime.ParseDuration(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse duration failed")
-				}
-				values[tf.Name] = duration.Duration()
-				return nil
-			})
-		case time.Time:
-			if found {
-				defaultValue, err := libtime.ParseTime(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = *defaultValue
-				}
-			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				t, err := libtime.ParseTime(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse time failed")
-				}
-				values[tf.Name] = *t
-				return nil
-			})
-		case *time.Time:
-			if found {
-				defaultValue, err := libtime.ParseTime(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = *defaultValue
-				}
-			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				t, err := libtime.ParseTime(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse time failed")
-				}
-				values[tf.Name] = *t
-				return nil
-			})
-		case *time.Duration:
-			if found {
-				defaultValue, err := libtime.ParseDuration(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = defaultValue.Duration()
-				}
-			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				duration, err := libtime.ParseDuration(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse duration failed")
-				}
-				values[tf.Name] = duration.Duration()
-				return nil
-			})
-		case libtime.Duration:
-			if found {
-				defaultValue, err := libtime.ParseDuration(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = *defaultValue
-				}
-			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				duration, err := libtime.ParseDuration(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse duration failed")
-				}
-				values[tf.Name] = *duration
-				return nil
-			})
-		case *libtime.Duration:
-			if found {
-				defaultValue, err := libtime.ParseDuration(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = *defaultValue
-				}
-			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				duration, err := libtime.ParseDuration(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse duration failed")
-				}
-				values[tf.Name] = *duration
-				return nil
-			})
-		case libtime.DateTime:
-			if found {
-				defaultValue, err := libtime.ParseDateTime(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = *defaultValue
-				}
-			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				dateTime, err := libtime.ParseDateTime(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse datetime failed")
-				}
-				values[tf.Name] = *dateTime
-				return nil
-			})
-		case *libtime.DateTime:
-			if found {
-				defaultValue, err := libtime.ParseDateTime(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = *defaultValue
-				}
-			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				dateTime, err := libtime.ParseDateTime(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse datetime failed")
-				}
-				values[tf.Name] = *dateTime
-				return nil
-			})
-		case libtime.Date:
-			if found {
-				defaultValue, err := libtime.ParseDate(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = *defaultValue
-				}
-			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				date, err := libtime.ParseDate(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse date failed")
-				}
-				values[tf.Name] = *date
-				return nil
-			})
-		case *libtime.Date:
-			if found {
-				defaultValue, err := libtime.ParseDate(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = *defaultValue
-				}
-			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				date, err := libtime.ParseDate(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse date failed")
-				}
-				values[tf.Name] = *date
-				return nil
-			})
-		case libtime.UnixTime:
-			if found {
-				defaultValue, err := libtime.ParseUnixTime(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = *defaultValue
-				}
+		return errors.Errorf(ctx, "flag %s of field %s is already defined", name, f.path)
+	}
+	flagSet.Var(&fieldFlag{
+		path:      f.path,
+		isBool:    valueType(f).Kind() == reflect.Bool,
+		set:       newFieldSetter(ctx, values, f, secrets),
+		separator: accumulateSeparator(f),
+	}, name, f.structField.Tag.Get("usage"))
+	return nil
+}
+
+// accumulateSeparator returns the separator of fields whose flag occurrences accumulate
+// (maps and slices with repeat tag) and "" for all other fields.
+func accumulateSeparator(f field) string {
+	if isListField(f) && valueType(f).Kind() == reflect.Map {
+		separator, _ := mapSeparators(f.structField)
+		return separator
+	}
+	if newRepeatedSlice(f).repeat {
+		return sliceSeparator(f.structField)
+	}
+	return ""
+}
+
+// newFieldSetter returns the func called for every occurrence of the flag of a field.
+// Maps merge all occurrences, slices follow the repeat tag and all other types keep the last
+// occurrence. Empty values are a parse error for bools and numbers and are ignored for pointers
+// and all other types except strings, maps and slices.
+func newFieldSetter(
+	ctx context.Context,
+	values map[string]interface{},
+	f field,
+	secrets secretResolvers,
+) func(value string) error {
+	repeated := newRepeatedSlice(f)
+	var mapValue reflect.Value
+	return func(value string) error {
+		value, err := secrets.resolve(ctx, f, value)
+		if err != nil {
+			return err
+		}
+		t := valueType(f)
+		if isListField(f) && t.Kind() == reflect.Map {
+			// The first flag replaces the default, later flags add to it
+			if !mapValue.IsValid() {
+				mapValue = reflect.MakeMap(t)
 			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				unixTime, err := libtime.ParseUnixTime(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse unixtime failed")
-				}
-				values[tf.Name] = *unixTime
-				return nil
-			})
-		case *libtime.UnixTime:
-			if found {
-				defaultValue, err := libtime.ParseUnixTime(ctx, defaultString)
-				if err != nil {
-					return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-				}
-				if defaultValue != nil {
-					values[tf.Name] = *defaultValue
-				}
+			separator, kvSeparator := mapSeparators(f.structField)
+			if err := mergeMapFromString(ctx, mapValue, value, separator, kvSeparator); err != nil {
+				return err
 			}
-			flag.CommandLine.Func(argName, usage, func(value string) error {
-				if value == "" {
-					return nil
-				}
-				unixTime, err := libtime.ParseUnixTime(ctx, value)
-				if err != nil {
-					return errors.Wrap(ctx, err, "parse unixtime failed")
-				}
-				values[tf.Name] = *unixTime
-				return nil
-			})
-		default:
-			// Check if type implements encoding.TextUnmarshaler BEFORE checking for slice
-			// This allows slice types like kafka.Brokers to implement TextUnmarshaler on the slice itself
-			ptrType := reflect.PointerTo(ef.Type())
-			if ptrType.Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
-				// Handle default value
-				if found && defaultString != "" {
-					unmarshaler := reflect.New(ef.Type()).Interface().(encoding.TextUnmarshaler)
-					if err := unmarshaler.UnmarshalText([]byte(defaultString)); err != nil {
-						return nil, errors.Wrapf(ctx, err, "unmarshal default value %q for field %s failed", defaultString, tf.Name)
-					}
-					values[tf.Name] = reflect.ValueOf(unmarshaler).Elem().Interface()
-				}
+			values[f.path] = mapValue.Interface()
+			return nil
+		}
+		if value == "" && !isListField(f) && t.Kind() != reflect.String && !rejectsEmptyArg(f) {
+			return nil
+		}
+		result, err := convertField(ctx, f, value)
+		var overflowError *OverflowError
+		if errors.As(err, &overflowError) {
+			return err
+		}
+		if err != nil {
+			return errors.Wrapf(ctx, err, "parse field %s failed", f.path)
+		}
+		values[f.path] = repeated.add(reflect.ValueOf(result))
+		return nil
+	}
+}
 
-				flag.CommandLine.Func(argName, usage, func(value string) error {
-					if value == "" {
-						return nil
-					}
-					unmarshaler := reflect.New(ef.Type()).Interface().(encoding.TextUnmarshaler)
-					if err := unmarshaler.UnmarshalText([]byte(value)); err != nil {
-						return errors.Wrap(ctx, err, "unmarshal text failed")
-					}
-					values[tf.Name] = reflect.ValueOf(unmarshaler).Elem().Interface()
-					return nil
-				})
-				continue
-			}
+// rejectsEmptyArg reports whether an empty flag value (e.g. -port=) is a parse error.
+// Like in the flag package this is the case for bools, numbers and named types of them.
+// Pointers stay nil and types with their own syntax like time.Duration keep their value.
+func rejectsEmptyArg(f field) bool {
+	t := f.value.Type()
+	kindType, ok := kindTypes[t.Kind()]
+	if !ok || t.Kind() == reflect.String {
+		return false
+	}
+	if t == kindType {
+		return true
+	}
+	if _, ok := LookupConverter(t); ok {
+		return false
+	}
+	return !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
 
-			// Check if it's a slice type (for slices that don't implement TextUnmarshaler)
-			if ef.Type().Kind() == reflect.Slice {
-				separator := tf.Tag.Get("separator")
-				if separator == "" {
-					separator = ","
-				}
-				elemType := ef.Type().Elem()
+// fieldFlag is the flag.Value of a field.
+type fieldFlag struct {
+	// path is the path of the field the flag belongs to.
+	path   string
+	isBool bool
+	set    func(value string) error
+	value  string
+	// separator joins all occurrences in raw, empty if only the last occurrence counts.
+	separator   string
+	occurrences []string
+}
 
-				// Handle default value for slices
-				if found && defaultString != "" {
-					parsed, err := parseSliceFromString(ctx, defaultString, separator, elemType)
-					if err != nil {
-						return nil, errors.Wrapf(ctx, err, "invalid default value %q for field %s", defaultString, tf.Name)
-					}
-					values[tf.Name] = parsed
-				}
+func (f *fieldFlag) String() string {
+	return f.value
+}
 
-				flag.CommandLine.Func(argName, usage, func(value string) error {
-					parsed, err := parseSliceFromString(ctx, value, separator, elemType)
-					if err != nil {
-						return err
-					}
-					values[tf.Name] = parsed
-					return nil
-				})
-				continue
-			}
+func (f *fieldFlag) Set(value string) error {
+	if err := f.set(value); err != nil {
+		return err
+	}
+	f.occurrences = append(f.occurrences, value)
+	f.value = value
+	return nil
+}
 
-			// Check if it's a custom type with underlying primitive type
-			if handled, err := handleCustomType(ctx, values, tf, ef, argName, defaultString, found, usage); handled {
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				return nil, errors.Errorf(ctx, "field %s with type %T is unsupported", tf.Name, ef.Interface())
-			}
-		}
+// raw returns the string the field value was converted from.
+func (f *fieldFlag) raw() string {
+	if f.separator == "" {
+		return f.value
 	}
-	if err := flag.CommandLine.Parse(args); err != nil {
-		return nil, errors.Wrap(ctx, err, "parse commandline failed")
+	return strings.Join(f.occurrences, f.separator)
+}
+
+// IsBoolFlag allows bool flags without value (e.g. -debug).
+func (f *fieldFlag) IsBoolFlag() bool {
+	return f.isBool
+}
+
+// repeatedSlice collects the values of a slice flag.
+// Without repeat:"true" every occurrence of the flag replaces the previous one.
+// With repeat:"true" every occurrence appends to the values of the previous ones.
+// Either way the flag replaces the env and default value as a whole.
+type repeatedSlice struct {
+	repeat bool
+	result reflect.Value
+}
+
+func newRepeatedSlice(f field) *repeatedSlice {
+	return &repeatedSlice{
+		repeat: f.structField.Tag.Get("repeat") == "true" && f.value.Kind() == reflect.Slice,
 	}
-	return values, nil
+}
+
+// add returns the values of all occurrences so far including the given one.
+func (r *repeatedSlice) add(value reflect.Value) interface{} {
+	if !r.repeat || !r.result.IsValid() {
+		r.result = value
+	} else {
+		r.result = reflect.AppendSlice(r.result, value)
+	}
+	return r.result.Interface()
 }
 
 // argsToValuesExplicit returns only values that were explicitly set via command-line arguments.
@@ -590,37 +357,57 @@ func argsToValues(
 // This is used internally to ensure proper precedence: args > env > defaults.
 func argsToValuesExplicit(
 	ctx context.Context,
+	flagSet *flag.FlagSet,
 	data interface{},
 	args []string,
+	secrets secretResolvers,
+	sources Provenance,
+	logger *slog.Logger,
+	gnu bool,
+	options fieldOptions,
 ) (map[string]interface{}, error) {
 	// First get all values (including defaults)
-	allValues, err := argsToValues(ctx, data, args)
+	allValues, err := argsToValues(ctx, flagSet, data, args, secrets, logger, gnu, options)
 	if err != nil {
 		return nil, err
 	}
 
 	// Then filter to only explicitly-set flags
 	actuallySet := make(map[string]interface{})
-	visitedFlags := make(map[string]bool)
-	flag.CommandLine.Visit(func(f *flag.Flag) {
-		visitedFlags[f.Name] = true
-	})
+	visitedFlags := visitedFlags(flagSet)
 
-	// Map flag names back to struct field names
-	e := reflect.ValueOf(data).Elem()
-	t := e.Type()
-	for i := 0; i < e.NumField(); i++ {
-		tf := t.Field(i)
-		argName, ok := tf.Tag.Lookup("arg")
-		if !ok {
-			continue
+	// Map flag names back to struct field paths
+	if err := walkFieldsWith(data, options, func(f field) error {
+		if !f.hasArg {
+			return nil
 		}
-		if visitedFlags[argName] {
-			if val, exists := allValues[tf.Name]; exists {
-				actuallySet[tf.Name] = val
+		name, visited := f.visitedArgName(visitedFlags)
+		if !visited {
+			return nil
+		}
+		if val, exists := allValues[f.path]; exists {
+			actuallySet[f.path] = val
+			source := Source{Kind: SourceKindFlag, Name: name}
+			if flagValue, ok := flagSet.Lookup(name).Value.(*fieldFlag); ok {
+				source.Raw = flagValue.raw()
 			}
+			sources.add(f, source)
 		}
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
+	if err := positionalToValues(
+		ctx,
+		data,
+		flagSet.Args(),
+		actuallySet,
+		secrets,
+		sources,
+		options,
+	); err != nil {
+		return nil, errors.Wrap(ctx, err, "parse positional arguments failed")
+	}
 	return actuallySet, nil
 }
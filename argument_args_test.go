@@ -1335,4 +1335,67 @@ var _ = Describe("ParseArgs", func() {
 			})
 		})
 	})
+
+	Context("repeated slice flags", func() {
+		It("keeps only the last occurrence without repeat tag", func() {
+			var args struct {
+				Tags []string `arg:"tag"`
+			}
+			err := argument.ParseArgs(ctx, &args, []string{"-tag=a", "-tag=b"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.Tags).To(Equal([]string{"b"}))
+		})
+		It("appends every occurrence with repeat tag", func() {
+			var args struct {
+				Tags []string `arg:"tag" repeat:"true"`
+			}
+			err := argument.ParseArgs(ctx, &args, []string{"-tag=a", "-tag", "b,c", "-tag=d"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.Tags).To(Equal([]string{"a", "b", "c", "d"}))
+		})
+		It("appends typed elements", func() {
+			var args struct {
+				Ports []int `arg:"port" repeat:"true" separator:";"`
+			}
+			err := argument.ParseArgs(ctx, &args, []string{"-port=80;443", "-port=8080"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.Ports).To(Equal([]int{80, 443, 8080}))
+		})
+		It("appends slice types implementing TextUnmarshaler", func() {
+			var args struct {
+				Brokers TestBrokers `arg:"broker" repeat:"true"`
+			}
+			err := argument.ParseArgs(
+				ctx,
+				&args,
+				[]string{"-broker=kafka1:9092", "-broker=ssl://kafka2:9093"},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.Brokers).To(Equal(TestBrokers{"plain://kafka1:9092", "ssl://kafka2:9093"}))
+		})
+		It("replaces the default", func() {
+			var args struct {
+				Tags []string `arg:"tag" repeat:"true" default:"x,y"`
+			}
+			err := argument.ParseArgs(ctx, &args, []string{"-tag=a", "-tag=b"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.Tags).To(Equal([]string{"a", "b"}))
+		})
+		It("replaces env values in Parse", func() {
+			parser := newTestParser([]string{"-tag=a", "-tag=b"}, []string{"TAGS=x,y"})
+			var args struct {
+				Tags []string `arg:"tag" env:"TAGS" repeat:"true" default:"z"`
+			}
+			Expect(parser.Parse(ctx, &args)).To(Succeed())
+			Expect(args.Tags).To(Equal([]string{"a", "b"}))
+		})
+		It("uses env values if the flag is not set", func() {
+			parser := newTestParser([]string{}, []string{"TAGS=x,y"})
+			var args struct {
+				Tags []string `arg:"tag" env:"TAGS" repeat:"true"`
+			}
+			Expect(parser.Parse(ctx, &args)).To(Succeed())
+			Expect(args.Tags).To(Equal([]string{"x", "y"}))
+		})
+	})
 })
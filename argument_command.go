@@ -0,0 +1,219 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o mocks/command.go --fake-name Command . Command
+
+// Command is a subcommand dispatched by Dispatch.
+// Use NewCommand to create a Command from a config struct and a run func.
+type Command interface {
+	// Name is the word on the command line that selects the command (e.g. "migrate").
+	Name() string
+	// Usage is a short description shown in the command list and the command help.
+	Usage() string
+	// Config returns a new pointer to the config struct of the command.
+	Config() interface{}
+	// Run executes the command with the parsed and validated config returned by Config.
+	Run(ctx context.Context, config interface{}) error
+}
+
+// NewCommand returns a Command that parses its flags into a new T and calls run with it.
+//
+// Example:
+//
+//	type MigrateConfig struct {
+//	    Steps int `arg:"steps" env:"MIGRATE_STEPS" default:"1"`
+//	}
+//
+//	argument.NewCommand("migrate", "run database migrations",
+//	    func(ctx context.Context, cfg *MigrateConfig) error {
+//	        return migrate(ctx, cfg.Steps)
+//	    },
+//	)
+func NewCommand[T any](
+	name string,
+	usage string,
+	run func(ctx context.Context, config *T) error,
+) Command {
+	return &command[T]{
+		name:  name,
+		usage: usage,
+		run:   run,
+	}
+}
+
+type command[T any] struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, config *T) error
+}
+
+func (c *command[T]) Name() string {
+	return c.name
+}
+
+func (c *command[T]) Usage() string {
+	return c.usage
+}
+
+func (c *command[T]) Config() interface{} {
+	return new(T)
+}
+
+func (c *command[T]) Run(ctx context.Context, config interface{}) error {
+	typed, ok := config.(*T)
+	if !ok {
+		return errors.Errorf(ctx, "command %s expects config %T, got %T", c.name, new(T), config)
+	}
+	return c.run(ctx, typed)
+}
+
+// Dispatch parses the global flags into global, selects the subcommand named by the first
+// argument after them, parses the remaining arguments into the config of the subcommand
+// and runs it. Environment variables, config files and defaults apply to both structs.
+// global may be nil if there are no global flags.
+//
+//	app [global flags] <command> [command flags]
+//
+// "app -h" and "app help" list the global flags and all commands,
+// "app <command> -h" and "app help <command>" show the flags of the command.
+// Help returns an error matching ErrHelp, even if required global flags are missing.
+// The package-level Dispatch uses flag.ExitOnError, so -h exits the process with status 0;
+// only "app help" returns ErrHelp there.
+//
+// Positional fields (pos tag) belong into the config of a command, in global they would
+// receive the command name.
+//
+// Example:
+//
+//	var global struct {
+//	    Debug bool `arg:"debug" env:"DEBUG"`
+//	}
+//	err := argument.Dispatch(ctx, &global,
+//	    argument.NewCommand("migrate", "run database migrations", runMigrate),
+//	    argument.NewCommand("serve", "start http server", runServe),
+//	)
+func Dispatch(ctx context.Context, global interface{}, commands ...Command) error {
+	return defaultParser.Dispatch(ctx, global, commands...)
+}
+
+func (p *parser) Dispatch(ctx context.Context, global interface{}, commands ...Command) error {
+	if global == nil {
+		global = &struct{}{}
+	}
+	flagSet := p.flagSet()
+	flagSet.Usage = func() {
+		writeCommandsUsage(flagSet.Output(), flagSet.Name(), global, commands, p.fieldOptions())
+	}
+	if _, err := p.parseOnly(ctx, flagSet, global, p.args()); err != nil {
+		return errors.Wrap(ctx, err, "parse global failed")
+	}
+
+	args := flagSet.Args()
+	if len(args) == 0 {
+		flagSet.Usage()
+		return errors.New(ctx, "command missing")
+	}
+	name, args := args[0], args[1:]
+	if name == "help" {
+		if len(args) == 0 {
+			flagSet.Usage()
+			return ErrHelp
+		}
+		name, args = args[0], []string{"-help"}
+	}
+	cmd := findCommand(commands, name)
+	if cmd == nil {
+		flagSet.Usage()
+		return errors.Errorf(ctx, "unknown command %q", name)
+	}
+
+	config := cmd.Config()
+	commandFlagSet := flag.NewFlagSet(flagSet.Name()+" "+cmd.Name(), flagSet.ErrorHandling())
+	commandFlagSet.SetOutput(flagSet.Output())
+	commandFlagSet.Usage = func() {
+		writeCommandUsage(
+			commandFlagSet.Output(),
+			commandFlagSet.Name(),
+			cmd,
+			config,
+			p.fieldOptions(),
+		)
+	}
+	if _, err := p.parseOnly(ctx, commandFlagSet, config, args); err != nil {
+		return errors.Wrapf(ctx, err, "parse command %s failed", cmd.Name())
+	}
+	if err := p.checkUnknownEnv(ctx, p.environ(), global, config); err != nil {
+		return errors.Wrapf(ctx, err, "parse command %s failed", cmd.Name())
+	}
+	// Global is validated after help was handled, so help needs no required global flags
+	if err := validate(ctx, global, p.fieldOptions()); err != nil {
+		return errors.Wrap(ctx, err, "validate global failed")
+	}
+	if err := validate(ctx, config, p.fieldOptions()); err != nil {
+		return errors.Wrapf(ctx, err, "validate command %s failed", cmd.Name())
+	}
+	if err := cmd.Run(ctx, config); err != nil {
+		return errors.Wrapf(ctx, err, "run command %s failed", cmd.Name())
+	}
+	return nil
+}
+
+func findCommand(commands []Command, name string) Command {
+	for _, cmd := range commands {
+		if cmd.Name() == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// writeCommandsUsage writes the global flags followed by the list of commands.
+func writeCommandsUsage(
+	w io.Writer,
+	name string,
+	global interface{},
+	commands []Command,
+	options fieldOptions,
+) {
+	fmt.Fprintf(w, "Usage of %s:\n\n  %s [flags] <command> [command flags]\n\n", name, name)
+	buf := &bytes.Buffer{}
+	_ = usage(buf, global, options)
+	if buf.Len() > 0 {
+		fmt.Fprintf(w, "%s\n", buf.String())
+	}
+	fmt.Fprintln(w, "Commands:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.Name(), cmd.Usage())
+	}
+	_ = tw.Flush()
+}
+
+// writeCommandUsage writes the description and flags of a single command.
+func writeCommandUsage(
+	w io.Writer,
+	name string,
+	cmd Command,
+	config interface{},
+	options fieldOptions,
+) {
+	fmt.Fprintf(w, "Usage of %s:\n\n", name)
+	if cmd.Usage() != "" {
+		fmt.Fprintf(w, "%s\n\n", cmd.Usage())
+	}
+	_ = usage(w, config, options)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+	"github.com/bborbe/argument/v2/mocks"
+)
+
+var _ = Describe("Dispatch", func() {
+	type globalConfig struct {
+		Debug bool   `arg:"debug" env:"DEBUG"`
+		DSN   string `arg:"dsn"   env:"DSN"   default:"postgres://localhost"`
+	}
+	type migrateConfig struct {
+		Steps int `arg:"steps" env:"MIGRATE_STEPS" default:"1" usage:"number of steps"`
+	}
+	type serveConfig struct {
+		Listen string `arg:"listen" env:"LISTEN" required:"true"`
+	}
+	var ctx context.Context
+	var output *bytes.Buffer
+	var global globalConfig
+	var migrated *migrateConfig
+	var served *serveConfig
+	var commands []argument.Command
+	var options []argument.Option
+	BeforeEach(func() {
+		ctx = context.Background()
+		output = &bytes.Buffer{}
+		options = []argument.Option{argument.WithName("app"), argument.WithOutput(output)}
+		global = globalConfig{}
+		migrated = nil
+		served = nil
+		commands = []argument.Command{
+			argument.NewCommand(
+				"migrate",
+				"run database migrations",
+				func(ctx context.Context, cfg *migrateConfig) error {
+					migrated = cfg
+					return nil
+				},
+			),
+			argument.NewCommand(
+				"serve",
+				"start http server",
+				func(ctx context.Context, cfg *serveConfig) error {
+					served = cfg
+					return nil
+				},
+			),
+		}
+	})
+	It("parses global and command flags and runs the command", func() {
+		parser := newTestParser([]string{"-debug", "migrate", "-steps=3"}, []string{}, options...)
+		Expect(parser.Dispatch(ctx, &global, commands...)).To(Succeed())
+		Expect(global.Debug).To(BeTrue())
+		Expect(global.DSN).To(Equal("postgres://localhost"))
+		Expect(migrated).NotTo(BeNil())
+		Expect(migrated.Steps).To(Equal(3))
+		Expect(served).To(BeNil())
+	})
+	It("uses env and defaults for command flags", func() {
+		environ := []string{"LISTEN=:8080", "DEBUG=true"}
+		parser := newTestParser([]string{"serve"}, environ, options...)
+		Expect(parser.Dispatch(ctx, &global, commands...)).To(Succeed())
+		Expect(global.Debug).To(BeTrue())
+		Expect(served).NotTo(BeNil())
+		Expect(served.Listen).To(Equal(":8080"))
+	})
+	It("accepts nil global", func() {
+		parser := newTestParser([]string{"migrate"}, []string{}, options...)
+		Expect(parser.Dispatch(ctx, nil, commands...)).To(Succeed())
+		Expect(migrated.Steps).To(Equal(1))
+	})
+	It("validates the command config", func() {
+		parser := newTestParser([]string{"serve"}, []string{}, options...)
+		err := parser.Dispatch(ctx, &global, commands...)
+		Expect(err).To(HaveOccurred())
+		Expect(errors.Is(err, argument.ErrRequired)).To(BeTrue())
+		Expect(served).To(BeNil())
+	})
+	It("returns error for missing command", func() {
+		parser := newTestParser([]string{"-debug"}, []string{}, options...)
+		err := parser.Dispatch(ctx, &global, commands...)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("command missing"))
+		Expect(output.String()).To(ContainSubstring("Commands:"))
+	})
+	It("returns error for unknown command", func() {
+		parser := newTestParser([]string{"backfill"}, []string{}, options...)
+		err := parser.Dispatch(ctx, &global, commands...)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unknown command "backfill"`))
+	})
+	It("prints global help", func() {
+		parser := newTestParser([]string{"-h"}, []string{}, options...)
+		err := parser.Dispatch(ctx, &global, commands...)
+		Expect(errors.Is(err, argument.ErrHelp)).To(BeTrue())
+		Expect(output.String()).To(Equal(`Usage of app:
+
+  app [flags] <command> [command flags]
+
+Options:
+  FLAG    ENV    TYPE    DEFAULT               REQUIRED  USAGE
+  -debug  DEBUG  bool
+  -dsn    DSN    string  postgres://localhost
+
+Commands:
+  migrate  run database migrations
+  serve    start http server
+`))
+	})
+	It("prints global help for help command", func() {
+		parser := newTestParser([]string{"help"}, []string{}, options...)
+		err := parser.Dispatch(ctx, &global, commands...)
+		Expect(errors.Is(err, argument.ErrHelp)).To(BeTrue())
+		Expect(output.String()).To(ContainSubstring("Commands:"))
+	})
+	It("prints command help", func() {
+		parser := newTestParser([]string{"migrate", "-help"}, []string{}, options...)
+		err := parser.Dispatch(ctx, &global, commands...)
+		Expect(errors.Is(err, argument.ErrHelp)).To(BeTrue())
+		Expect(migrated).To(BeNil())
+		Expect(
+			output.String(),
+		).To(HavePrefix("Usage of app migrate:\n\nrun database migrations\n\n"))
+		Expect(output.String()).To(ContainSubstring("number of steps"))
+		Expect(output.String()).NotTo(ContainSubstring("-dsn"))
+	})
+	It("prints command help for help command", func() {
+		parser := newTestParser([]string{"help", "serve"}, []string{}, options...)
+		err := parser.Dispatch(ctx, &global, commands...)
+		Expect(errors.Is(err, argument.ErrHelp)).To(BeTrue())
+		Expect(output.String()).To(ContainSubstring("Usage of app serve:"))
+		Expect(output.String()).To(ContainSubstring("-listen"))
+	})
+	DescribeTable("prints help without required global flags",
+		func(args []string, usage string) {
+			var required struct {
+				Token string `arg:"token" env:"TOKEN" required:"true"`
+			}
+			err := newTestParser(args, []string{}, options...).Dispatch(ctx, &required, commands...)
+			Expect(errors.Is(err, argument.ErrHelp)).To(BeTrue())
+			Expect(output.String()).To(HavePrefix(usage))
+		},
+		Entry("help", []string{"help"}, "Usage of app:"),
+		Entry("help command", []string{"help", "migrate"}, "Usage of app migrate:"),
+		Entry("command -h", []string{"migrate", "-h"}, "Usage of app migrate:"),
+	)
+	It("validates required global flags", func() {
+		var required struct {
+			Token string `arg:"token" env:"TOKEN" required:"true"`
+		}
+		parser := newTestParser([]string{"migrate"}, []string{}, options...)
+		err := parser.Dispatch(ctx, &required, commands...)
+		Expect(errors.Is(err, argument.ErrRequired)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("validate global failed"))
+		Expect(migrated).To(BeNil())
+	})
+	It("returns the error of the command", func() {
+		command := &mocks.Command{}
+		command.NameReturns("fail")
+		command.ConfigReturns(&struct{}{})
+		command.RunReturns(errors.New(ctx, "banana"))
+		parser := newTestParser([]string{"fail"}, []string{}, options...)
+		err := parser.Dispatch(ctx, &global, command)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("run command fail failed: banana"))
+		Expect(command.RunCallCount()).To(Equal(1))
+	})
+})
@@ -0,0 +1,597 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+)
+
+// Converter converts the string syntax used by args, env vars, config files and defaults
+// into a value of one type. All sources, Validate and Print use the same converters.
+type Converter struct {
+	// Parse converts value into the registered type.
+	Parse func(ctx context.Context, value string) (interface{}, error)
+	// IsZero reports whether a required field is empty (optional).
+	// Defaults to the zero value check of reflect.
+	IsZero func(value interface{}) bool
+	// Format returns the string printed by Print (optional).
+	// Defaults to the value itself.
+	Format func(value interface{}) string
+}
+
+var registry = struct {
+	mux        sync.RWMutex
+	converters map[reflect.Type]Converter
+}{
+	converters: make(map[reflect.Type]Converter),
+}
+
+// RegisterType registers the converter for fields of type t and *t.
+// It replaces the built-in converter of t, if any. Call it before parsing, e.g. in init.
+// Values of registered types are set directly and do not need to support JSON.
+//
+// Example:
+//
+//	argument.RegisterType(reflect.TypeOf(decimal.Decimal{}), argument.Converter{
+//	    Parse: func(ctx context.Context, value string) (interface{}, error) {
+//	        return decimal.NewFromString(value)
+//	    },
+//	    IsZero: func(value interface{}) bool {
+//	        return value.(decimal.Decimal).IsZero()
+//	    },
+//	})
+func RegisterType(t reflect.Type, converter Converter) {
+	registry.mux.Lock()
+	defer registry.mux.Unlock()
+	registry.converters[t] = converter
+}
+
+// LookupConverter returns the converter of type t, registered or built-in.
+func LookupConverter(t reflect.Type) (Converter, bool) {
+	if converter, ok := registeredConverter(t); ok {
+		return converter, true
+	}
+	converter, ok := builtinConverters[t]
+	return converter, ok
+}
+
+func registeredConverter(t reflect.Type) (Converter, bool) {
+	registry.mux.RLock()
+	defer registry.mux.RUnlock()
+	converter, ok := registry.converters[t]
+	return converter, ok
+}
+
+// builtinConverters are the converters of all types supported without registration.
+var builtinConverters = map[reflect.Type]Converter{
+	reflect.TypeOf(""): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			return value, nil
+		},
+	},
+	reflect.TypeOf(false): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse bool %q failed", value)
+			}
+			return v, nil
+		},
+	},
+	reflect.TypeOf(int(0)):     intConverter(reflect.TypeOf(int(0))),
+	reflect.TypeOf(int8(0)):    intConverter(reflect.TypeOf(int8(0))),
+	reflect.TypeOf(int16(0)):   intConverter(reflect.TypeOf(int16(0))),
+	reflect.TypeOf(int32(0)):   intConverter(reflect.TypeOf(int32(0))),
+	reflect.TypeOf(int64(0)):   intConverter(reflect.TypeOf(int64(0))),
+	reflect.TypeOf(uint(0)):    uintConverter(reflect.TypeOf(uint(0))),
+	reflect.TypeOf(uint8(0)):   uintConverter(reflect.TypeOf(uint8(0))),
+	reflect.TypeOf(uint16(0)):  uintConverter(reflect.TypeOf(uint16(0))),
+	reflect.TypeOf(uint32(0)):  uintConverter(reflect.TypeOf(uint32(0))),
+	reflect.TypeOf(uint64(0)):  uintConverter(reflect.TypeOf(uint64(0))),
+	reflect.TypeOf(float32(0)): floatConverter(reflect.TypeOf(float32(0))),
+	reflect.TypeOf(float64(0)): floatConverter(reflect.TypeOf(float64(0))),
+	reflect.TypeOf(time.Duration(0)): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			duration, err := libtime.ParseDuration(ctx, value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse duration %q failed", value)
+			}
+			return duration.Duration(), nil
+		},
+	},
+	reflect.TypeOf(time.Time{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			t, err := libtime.ParseTime(ctx, value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse time %q failed", value)
+			}
+			return *t, nil
+		},
+	},
+	reflect.TypeOf(libtime.Duration(0)): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			duration, err := libtime.ParseDuration(ctx, value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse duration %q failed", value)
+			}
+			return *duration, nil
+		},
+	},
+	reflect.TypeOf(libtime.DateTime{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			dateTime, err := libtime.ParseDateTime(ctx, value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse datetime %q failed", value)
+			}
+			return *dateTime, nil
+		},
+	},
+	reflect.TypeOf(libtime.Date{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			date, err := libtime.ParseDate(ctx, value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse date %q failed", value)
+			}
+			return *date, nil
+		},
+	},
+	reflect.TypeOf(libtime.UnixTime{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			unixTime, err := libtime.ParseUnixTime(ctx, value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse unixtime %q failed", value)
+			}
+			return *unixTime, nil
+		},
+	},
+	reflect.TypeOf(net.IP{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, errors.Errorf(ctx, "parse ip %q failed", value)
+			}
+			return ip, nil
+		},
+		Format: func(value interface{}) string {
+			return value.(net.IP).String()
+		},
+	},
+	reflect.TypeOf(net.HardwareAddr{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			hardwareAddr, err := net.ParseMAC(value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse mac %q failed", value)
+			}
+			return hardwareAddr, nil
+		},
+		Format: func(value interface{}) string {
+			return value.(net.HardwareAddr).String()
+		},
+	},
+	reflect.TypeOf(netip.Addr{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse addr %q failed", value)
+			}
+			return addr, nil
+		},
+		Format: func(value interface{}) string {
+			return value.(netip.Addr).String()
+		},
+	},
+	reflect.TypeOf(netip.Prefix{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse prefix %q failed", value)
+			}
+			return prefix, nil
+		},
+		Format: func(value interface{}) string {
+			return value.(netip.Prefix).String()
+		},
+	},
+	reflect.TypeOf(netip.AddrPort{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			addrPort, err := netip.ParseAddrPort(value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse addrport %q failed", value)
+			}
+			return addrPort, nil
+		},
+		Format: func(value interface{}) string {
+			return value.(netip.AddrPort).String()
+		},
+	},
+	reflect.TypeOf(url.URL{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			u, err := url.Parse(value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse url %q failed", value)
+			}
+			return *u, nil
+		},
+		// Print never shows the password of a URL
+		Format: func(value interface{}) string {
+			u := value.(url.URL)
+			return u.Redacted()
+		},
+	},
+	reflect.TypeOf(regexp.Regexp{}): {
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse regexp %q failed", value)
+			}
+			return *re, nil
+		},
+		Format: func(value interface{}) string {
+			re := value.(regexp.Regexp)
+			return re.String()
+		},
+	},
+}
+
+// kindTypes maps the kind of named types like `type Username string` to the built-in type
+// whose converter is used for them.
+var kindTypes = map[reflect.Kind]reflect.Type{
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
+
+// intConverter returns the converter of a signed integer type with range check.
+// Like the flag package it accepts the base prefixes 0x, 0o, 0b and 0 (e.g. 0x10 is 16).
+func intConverter(t reflect.Type) Converter {
+	return Converter{
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			v, err := strconv.ParseInt(value, 0, t.Bits())
+			if err != nil {
+				return nil, numberError(ctx, err, value, t)
+			}
+			return reflect.ValueOf(v).Convert(t).Interface(), nil
+		},
+	}
+}
+
+// uintConverter returns the converter of an unsigned integer type with range check.
+// Like the flag package it accepts the base prefixes 0x, 0o, 0b and 0 (e.g. 0x10 is 16).
+func uintConverter(t reflect.Type) Converter {
+	return Converter{
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			v, err := strconv.ParseUint(value, 0, t.Bits())
+			if err != nil {
+				return nil, numberError(ctx, err, value, t)
+			}
+			return reflect.ValueOf(v).Convert(t).Interface(), nil
+		},
+	}
+}
+
+// floatConverter returns the converter of a float type with range check.
+func floatConverter(t reflect.Type) Converter {
+	return Converter{
+		Parse: func(ctx context.Context, value string) (interface{}, error) {
+			v, err := strconv.ParseFloat(value, t.Bits())
+			if err != nil {
+				return nil, numberError(ctx, err, value, t)
+			}
+			return reflect.ValueOf(v).Convert(t).Interface(), nil
+		},
+	}
+}
+
+// numberError returns an OverflowError if value is out of the range of t.
+func numberError(ctx context.Context, err error, value string, t reflect.Type) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return &OverflowError{Value: value, Type: t.String()}
+	}
+	return errors.Wrapf(ctx, err, "parse %v %q failed", t, value)
+}
+
+// OverflowError is returned for a number out of the range of the field type,
+// e.g. "value 300 overflows uint8 for field Retries".
+type OverflowError struct {
+	// Value is the number as given.
+	Value string
+	// Type is the type of the field (e.g. "uint8").
+	Type string
+	// Field is the dotted path of the field, empty if unknown.
+	Field string
+}
+
+func (o *OverflowError) Error() string {
+	if o.Field == "" {
+		return fmt.Sprintf("value %s overflows %s", o.Value, o.Type)
+	}
+	return fmt.Sprintf("value %s overflows %s for field %s", o.Value, o.Type, o.Field)
+}
+
+// isScalarType reports whether values of t are converted from a single string.
+// These are types with a converter, types implementing encoding.TextUnmarshaler
+// and named types of a supported kind.
+func isScalarType(t reflect.Type) bool {
+	if _, ok := LookupConverter(t); ok {
+		return true
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	_, ok := kindTypes[t.Kind()]
+	return ok
+}
+
+// convertedType returns the type convert returns for t. Named types without converter and
+// TextUnmarshaler are converted into the built-in type of their kind, Fill converts them via JSON.
+// Pointer types like *url.URL are converted into pointers to the converted type of their element.
+func convertedType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return reflect.PointerTo(convertedType(t.Elem()))
+	}
+	if _, ok := LookupConverter(t); ok || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return t
+	}
+	if kindType, ok := kindTypes[t.Kind()]; ok {
+		return kindType
+	}
+	return t
+}
+
+// convert converts value into type t. The registered or built-in converter of t is used first,
+// then encoding.TextUnmarshaler, then the converter of the kind of t.
+// The result has the type returned by convertedType.
+func convert(ctx context.Context, value string, t reflect.Type) (reflect.Value, error) {
+	if converter, ok := LookupConverter(t); ok {
+		result, err := converter.Parse(ctx, value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(result), nil
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		result := reflect.New(t)
+		if err := result.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return reflect.Value{}, errors.Wrapf(ctx, err, "unmarshal text %q failed", value)
+		}
+		return result.Elem(), nil
+	}
+	kindType, ok := kindTypes[t.Kind()]
+	if !ok {
+		return reflect.Value{}, errors.Errorf(ctx, "unsupported type: %v", t)
+	}
+	result, err := builtinConverters[kindType].Parse(ctx, value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(result), nil
+}
+
+// isElemType reports whether t is supported as slice element. These are scalar types and
+// pointers to scalar types like *url.URL.
+func isElemType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return isScalarType(t)
+}
+
+// convertElem converts value into the slice element type t. Pointer types get a pointer to
+// the converted value.
+func convertElem(ctx context.Context, value string, t reflect.Type) (reflect.Value, error) {
+	if t.Kind() != reflect.Pointer {
+		return convert(ctx, value, t)
+	}
+	result, err := convert(ctx, value, t.Elem())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	ptr := reflect.New(result.Type())
+	ptr.Elem().Set(result)
+	return ptr, nil
+}
+
+// valueType returns the type a field value is converted into. Pointers are converted
+// into their element type, Fill sets the pointer.
+func valueType(f field) reflect.Type {
+	t := f.value.Type()
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// isSupported reports whether the field can be converted from a string.
+func isSupported(f field) bool {
+	t := valueType(f)
+	return isScalarType(t) || t.Kind() == reflect.Map || t.Kind() == reflect.Slice
+}
+
+// isListField reports whether the field is a map or slice split by its separators.
+func isListField(f field) bool {
+	t := valueType(f)
+	return !isScalarType(t) && (t.Kind() == reflect.Map || t.Kind() == reflect.Slice)
+}
+
+// convertField converts value into the type of the field.
+// Maps and slices without converter are split by the separator tags and converted per element.
+// An OverflowError is returned with the field path set.
+func convertField(ctx context.Context, f field, value string) (interface{}, error) {
+	result, err := convertFieldValue(ctx, f, value)
+	var overflowError *OverflowError
+	if errors.As(err, &overflowError) {
+		return nil, &OverflowError{
+			Value: overflowError.Value,
+			Type:  overflowError.Type,
+			Field: f.path,
+		}
+	}
+	return result, err
+}
+
+func convertFieldValue(ctx context.Context, f field, value string) (interface{}, error) {
+	t := valueType(f)
+	if isScalarType(t) {
+		result, err := convert(ctx, value, t)
+		if err != nil {
+			return nil, err
+		}
+		return result.Interface(), nil
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		separator, kvSeparator := mapSeparators(f.structField)
+		return parseMapFromString(ctx, value, separator, kvSeparator, t)
+	case reflect.Slice:
+		return parseSliceFromString(ctx, value, sliceSeparator(f.structField), t.Elem())
+	}
+	return nil, errors.Errorf(
+		ctx,
+		"field %s with type %T is unsupported",
+		f.path,
+		f.value.Interface(),
+	)
+}
+
+// stringToValue converts the given string into the type of the field and stores it in values
+// under the field path. It is used for env vars, config files and defaults.
+func stringToValue(
+	ctx context.Context,
+	values map[string]interface{},
+	f field,
+	value string,
+) error {
+	if !isSupported(f) {
+		return errors.Errorf(
+			ctx,
+			"field %s with type %T is unsupported",
+			f.path,
+			f.value.Interface(),
+		)
+	}
+	result, err := convertField(ctx, f, value)
+	var overflowError *OverflowError
+	if errors.As(err, &overflowError) {
+		return err
+	}
+	if err != nil {
+		return errors.Errorf(
+			ctx,
+			"parse field %s as %T failed: %v",
+			f.path,
+			f.value.Interface(),
+			err,
+		)
+	}
+	values[f.path] = result
+	return nil
+}
+
+// isEmpty reports whether a required field is empty. Bools are never empty, pointers are
+// empty if nil, maps and slices if they have no elements and all other types if they
+// are zero or the IsZero func of their converter says so.
+func isEmpty(ctx context.Context, f field) (bool, error) {
+	ef := f.value
+	switch ef.Kind() {
+	case reflect.Pointer:
+		return ef.IsNil(), nil
+	case reflect.Slice, reflect.Map:
+		return ef.Len() == 0, nil
+	case reflect.Bool:
+		return false, nil
+	}
+	if converter, ok := LookupConverter(ef.Type()); ok && converter.IsZero != nil {
+		return converter.IsZero(ef.Interface()), nil
+	}
+	if !isScalarType(ef.Type()) {
+		return false, errors.Errorf(
+			ctx,
+			"field %s with type %T is unsupported",
+			f.path,
+			ef.Interface(),
+		)
+	}
+	return ef.IsZero(), nil
+}
+
+// formatValue applies the Format func of the converter of value, if any. Pointers are
+// dereferenced and slices and maps are formatted per element, so the password of a
+// []url.URL is not printed either.
+func formatValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	t := reflect.TypeOf(value)
+	if converter, ok := LookupConverter(t); ok {
+		if converter.Format != nil {
+			return converter.Format(value)
+		}
+		return value
+	}
+	if !hasFormat(t) {
+		return value
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		return formatValue(rv.Elem().Interface())
+	case reflect.Slice:
+		result := make([]interface{}, rv.Len())
+		for i := range result {
+			result[i] = formatValue(rv.Index(i).Interface())
+		}
+		return result
+	case reflect.Map:
+		elemType := reflect.TypeOf((*interface{})(nil)).Elem()
+		result := reflect.MakeMapWithSize(reflect.MapOf(t.Key(), elemType), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			formatted := formatValue(iter.Value().Interface())
+			result.SetMapIndex(iter.Key(), reflect.ValueOf(&formatted).Elem())
+		}
+		return result.Interface()
+	}
+	return value
+}
+
+// hasFormat reports whether t, the element type of the pointer, slice or map t or their
+// element types have a converter with Format func.
+func hasFormat(t reflect.Type) bool {
+	if converter, ok := LookupConverter(t); ok {
+		return converter.Format != nil
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return hasFormat(t.Elem())
+	}
+	return false
+}
@@ -69,7 +69,7 @@ var _ = Describe("DefaultValues", func() {
 		Expect(err).NotTo(HaveOccurred())
 		value, ok := data["Age"]
 		Expect(ok).To(BeTrue())
-		Expect(value).To(Equal(uint64(29)))
+		Expect(value).To(Equal(uint(29)))
 	})
 	It("return error if parse uint fails", func() {
 		var args struct {
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+//counterfeiter:generate -o mocks/has_env_prefix.go --fake-name HasEnvPrefix . HasEnvPrefix
+
+// HasEnvPrefix can be implemented by the top-level config struct to put all its env vars
+// into a namespace. The prefix is added in front of every env, envAlias and envFile tag,
+// so Parse, Usage, Print and the validation errors all use the prefixed names.
+//
+// Example:
+//
+//	type Config struct {
+//	    Port int `arg:"port" env:"PORT"` // reads ORDERS_PORT
+//	}
+//
+//	func (c Config) EnvPrefix() string {
+//	    return "ORDERS_"
+//	}
+type HasEnvPrefix interface {
+	// EnvPrefix returns the prefix of all env names (e.g. "ORDERS_").
+	EnvPrefix() string
+}
+
+// WithEnvPrefix adds prefix in front of every env, envAlias and envFile tag, so the same
+// binary can run several times with separate env namespaces (e.g. env:"PORT" reads ORDERS_PORT).
+// It takes precedence over the EnvPrefix of a struct implementing HasEnvPrefix.
+// CONFIG_FILE and the names of env:// secret references are not prefixed.
+func WithEnvPrefix(prefix string) Option {
+	return func(p *parser) {
+		p.envPrefix = prefix
+	}
+}
+
+// rootPrefix returns the prefix of the top-level fields of data. The env prefix is the one of
+// WithEnvPrefix if set, otherwise the EnvPrefix of data if it implements HasEnvPrefix.
+func rootPrefix(data interface{}, options fieldOptions) fieldPrefix {
+	envPrefix := options.envPrefix
+	if envPrefix == "" {
+		if hasEnvPrefix, ok := data.(HasEnvPrefix); ok {
+			envPrefix = hasEnvPrefix.EnvPrefix()
+		}
+	}
+	return fieldPrefix{env: envPrefix, naming: options.naming}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+type envPrefixConfig struct {
+	Port  int    `arg:"port"  env:"PORT"  required:"true"`
+	Topic string `arg:"topic" env:"TOPIC"                 envAlias:"KAFKA_TOPIC"`
+}
+
+func (c envPrefixConfig) EnvPrefix() string {
+	return "ORDERS_"
+}
+
+var _ = Describe("Env prefix", func() {
+	type kafka struct {
+		Brokers string `env:"BROKERS"`
+	}
+	type config struct {
+		Port     int    `arg:"port"     env:"PORT"     required:"true"`
+		Password string `arg:"password" env:"PASSWORD"                 display:"length" envFile:"PASSWORD_FILE"`
+		Kafka    kafka  `               env:"KAFKA_"`
+	}
+	var ctx context.Context
+	var cfg config
+	BeforeEach(func() {
+		ctx = context.Background()
+		cfg = config{}
+	})
+	It("reads prefixed env vars", func() {
+		provenance, err := newTestParser(
+			nil,
+			[]string{
+				"PORT=1",
+				"ORDERS_PORT=8080",
+				"ORDERS_KAFKA_BROKERS=kafka:9092",
+				"ORDERS_PASSWORD_FILE=/dev/null",
+			},
+			argument.WithEnvPrefix("ORDERS_"),
+		).ParseWithReport(ctx, &cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Port).To(Equal(8080))
+		Expect(cfg.Kafka.Brokers).To(Equal("kafka:9092"))
+		Expect(provenance["Port"].String()).To(Equal("env ORDERS_PORT"))
+		Expect(provenance["Password"].Name).To(Equal("ORDERS_PASSWORD_FILE"))
+	})
+	It("reports the prefixed name for required fields", func() {
+		parser := newTestParser(nil, []string{"PORT=8080"}, argument.WithEnvPrefix("ORDERS_"))
+		err := parser.Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"Required field empty, define parameter port or define env ORDERS_PORT",
+		))
+		var validationErrors argument.ValidationErrors
+		Expect(errors.As(err, &validationErrors)).To(BeTrue())
+		Expect(validationErrors[0].Env).To(Equal("ORDERS_PORT"))
+	})
+	It("shows the prefixed name in usage and print", func() {
+		output := &bytes.Buffer{}
+		err := newTestParser(
+			[]string{"-h"},
+			[]string{"ORDERS_PORT=8080"},
+			argument.WithEnvPrefix("ORDERS_"),
+			argument.WithOutput(output),
+		).ParseOnly(ctx, &cfg)
+		Expect(errors.Is(err, argument.ErrHelp)).To(BeTrue())
+		Expect(output.String()).To(ContainSubstring("-port      ORDERS_PORT"))
+		Expect(output.String()).To(ContainSubstring("ORDERS_KAFKA_BROKERS"))
+
+		printed := &bytes.Buffer{}
+		Expect(newTestParser(
+			nil,
+			[]string{"ORDERS_PORT=8080"},
+			argument.WithEnvPrefix("ORDERS_"),
+			argument.WithPrinter(argument.NewTextPrinter(printed)),
+		).ParseAndPrint(ctx, &cfg)).To(Succeed())
+		Expect(printed.String()).To(ContainSubstring("(env ORDERS_PORT)"))
+	})
+	It("uses the EnvPrefix of the struct", func() {
+		var prefixed envPrefixConfig
+		Expect(newTestParser(nil, []string{"ORDERS_PORT=8080", "ORDERS_KAFKA_TOPIC=orders"}).
+			Parse(ctx, &prefixed)).To(Succeed())
+		Expect(prefixed).To(Equal(envPrefixConfig{Port: 8080, Topic: "orders"}))
+
+		buf := &bytes.Buffer{}
+		Expect(argument.Usage(buf, &prefixed)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("ORDERS_PORT"))
+
+		err := argument.ValidateRequired(ctx, &envPrefixConfig{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("define env ORDERS_PORT"))
+	})
+	It("prefers WithEnvPrefix over the EnvPrefix of the struct", func() {
+		var prefixed envPrefixConfig
+		Expect(newTestParser(
+			nil,
+			[]string{"ORDERS_PORT=1", "PAYMENTS_PORT=8080"},
+			argument.WithEnvPrefix("PAYMENTS_"),
+		).Parse(ctx, &prefixed)).To(Succeed())
+		Expect(prefixed.Port).To(Equal(8080))
+	})
+	It("works with ParseEnv and WithStrictEnv", func() {
+		parser := argument.NewParser(
+			argument.WithEnvPrefix("ORDERS_"),
+			argument.WithStrictEnv("ORDERS_"),
+		)
+		Expect(parser.ParseEnv(ctx, &cfg, []string{"ORDERS_PORT=8080"})).To(Succeed())
+		Expect(cfg.Port).To(Equal(8080))
+		err := parser.ParseEnv(ctx, &cfg, []string{"ORDERS_PROT=8080"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("did you mean ORDERS_PORT?"))
+	})
+})
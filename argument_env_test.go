@@ -5,7 +5,11 @@
 package argument_test
 
 import (
+	"bytes"
 	"context"
+	"log"
+	"os"
+	"path/filepath"
 	"time"
 
 	libtime "github.com/bborbe/time"
@@ -307,12 +311,22 @@ var _ = Describe("ParseEnv", func() {
 			Expect(args.Username).To(Equal("uppercase"))
 		})
 
-		It("returns error for unsupported *float64 pointer type", func() {
+		It("parses *float64 pointer type", func() {
 			var args struct {
 				Amount *float64 `env:"amount"`
 			}
 			err := argument.ParseEnv(ctx, &args, []string{"amount=123.45"})
-			Expect(err).To(HaveOccurred()) // *float64 not supported in env parsing
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.Amount).NotTo(BeNil())
+			Expect(*args.Amount).To(Equal(123.45))
+		})
+
+		It("returns error for unsupported type", func() {
+			var args struct {
+				Channel chan int `env:"channel"`
+			}
+			err := argument.ParseEnv(ctx, &args, []string{"channel=1"})
+			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("unsupported"))
 		})
 
@@ -623,4 +637,74 @@ var _ = Describe("ParseEnv", func() {
 			Expect(args.UnixTS.Unix()).To(Equal(int64(1704067200)))
 		})
 	})
+
+	Context("secrets from files", func() {
+		var path string
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "secret")
+			Expect(os.WriteFile(path, []byte("S3CR3T\n"), 0600)).To(Succeed())
+		})
+		It("reads the value from the file of the envFile env", func() {
+			var args struct {
+				Password string `envFile:"DB_PASSWORD_PATH"`
+			}
+			err := argument.ParseEnv(ctx, &args, []string{"DB_PASSWORD_PATH=" + path})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.Password).To(Equal("S3CR3T"))
+		})
+		It("prefers the env value over the file", func() {
+			var args struct {
+				Password string `env:"DB_PASSWORD" envFile:"DB_PASSWORD_FILE"`
+			}
+			err := argument.ParseEnv(ctx, &args, []string{
+				"DB_PASSWORD=direct",
+				"DB_PASSWORD_FILE=" + path,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.Password).To(Equal("direct"))
+		})
+		It("converts the file content to the field type", func() {
+			Expect(os.WriteFile(path, []byte("8080\r\n"), 0600)).To(Succeed())
+			var args struct {
+				Port int `envFile:"PORT_FILE"`
+			}
+			err := argument.ParseEnv(ctx, &args, []string{"PORT_FILE=" + path})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.Port).To(Equal(8080))
+		})
+		It("returns error if the file does not exist", func() {
+			var args struct {
+				Password string `envFile:"DB_PASSWORD_FILE"`
+			}
+			err := argument.ParseEnv(ctx, &args, []string{"DB_PASSWORD_FILE=/does/not/exist"})
+			Expect(err).To(HaveOccurred())
+			Expect(
+				err.Error(),
+			).To(ContainSubstring("read file /does/not/exist of env DB_PASSWORD_FILE failed"))
+		})
+		It("prints envFile fields with length by default", func() {
+			buf := &bytes.Buffer{}
+			log.SetOutput(buf)
+			log.SetFlags(0)
+			args := struct {
+				Password string `envFile:"DB_PASSWORD_PATH"`
+				Token    string `envFile:"TOKEN_PATH"       display:"hidden"`
+			}{
+				Password: "S3CR3T",
+				Token:    "token",
+			}
+			Expect(argument.Print(ctx, &args)).To(Succeed())
+			Expect(buf.String()).To(Equal("Argument: Password length 6\n"))
+		})
+		It("does not read a file for fields without envFile", func() {
+			var args struct {
+				Key     string `env:"TLS_KEY"`
+				KeyFile string `env:"TLS_KEY_FILE"`
+			}
+			err := argument.ParseEnv(ctx, &args, []string{"TLS_KEY_FILE=" + path})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.Key).To(BeEmpty())
+			Expect(args.KeyFile).To(Equal(path))
+		})
+	})
 })
@@ -0,0 +1,259 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+)
+
+// field describes a single (possibly nested) struct field that can receive a value.
+type field struct {
+	// path is the dotted Go field path (e.g. "Kafka.Brokers") used as key in value maps.
+	path string
+	// structField is the reflect description of the field itself.
+	structField reflect.StructField
+	// value is the current value of the field.
+	value reflect.Value
+	// argName is the command-line argument name including all parent prefixes.
+	argName string
+	hasArg  bool
+	// envName is the environment variable name including all parent prefixes.
+	envName string
+	hasEnv  bool
+	// envFileName is the name of the envFile tag including all parent prefixes.
+	envFileName string
+	hasEnvFile  bool
+	// secret is set by secret:"true", the value is resolved by a SecretResolver.
+	secret bool
+	// filePath is the key path of the field inside a config file, including all parent keys.
+	filePath []string
+	hasFile  bool
+	// argAliases and envAliases are the names of the alias and envAlias tags including all
+	// parent prefixes.
+	argAliases []string
+	envAliases []string
+	// deprecated is the message of the deprecated tag.
+	deprecated    string
+	hasDeprecated bool
+	// pos is the pos tag, the index of a positional argument or "rest".
+	pos    string
+	hasPos bool
+}
+
+// fieldPrefix holds the names inherited from parent struct fields.
+type fieldPrefix struct {
+	path string
+	arg  string
+	env  string
+	file []string
+	// naming derives names of fields without arg or env tag, see WithNaming.
+	naming Naming
+}
+
+// fieldOptions holds the parser options that change the names of fields.
+type fieldOptions struct {
+	// envPrefix is set by WithEnvPrefix.
+	envPrefix string
+	// naming is set by WithNaming.
+	naming Naming
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// walkFields calls fn for every exported leaf field of the struct data points to.
+//
+// Struct and *struct fields that do not implement encoding.TextUnmarshaler are not
+// passed to fn, but walked recursively. Their arg and env tags act as prefixes for
+// the names of the nested fields:
+//
+//	type Config struct {
+//	    Kafka KafkaConfig `arg:"kafka-" env:"KAFKA_"`
+//	}
+//
+//	type KafkaConfig struct {
+//	    Brokers string `arg:"brokers" env:"BROKERS"` // -kafka-brokers and KAFKA_BROKERS
+//	}
+//
+// The file tag of a struct field names the nested object in a config file instead.
+//
+// Nil *struct fields are walked using a zero value, so their fields can still be registered.
+//
+// Env names start with the EnvPrefix of data if it implements HasEnvPrefix.
+// Tags set to "auto" derive the name from the Go field name, tags set to "-" skip it.
+func walkFields(data interface{}, fn func(f field) error) error {
+	return walkFieldsWith(data, fieldOptions{}, fn)
+}
+
+// walkFieldsWith works like walkFields with the env prefix and naming of the parser.
+func walkFieldsWith(data interface{}, options fieldOptions, fn func(f field) error) error {
+	e := reflect.ValueOf(data).Elem()
+	return walkStruct(
+		e,
+		rootPrefix(data, options),
+		map[reflect.Type]bool{e.Type(): true},
+		fn,
+	)
+}
+
+func walkStruct(
+	e reflect.Value,
+	prefix fieldPrefix,
+	visited map[reflect.Type]bool,
+	fn func(f field) error,
+) error {
+	t := e.Type()
+	for i := 0; i < e.NumField(); i++ {
+		tf := t.Field(i)
+		if !tf.IsExported() {
+			continue
+		}
+		ef := e.Field(i)
+
+		if isNestedStruct(tf.Type) {
+			structType := tf.Type
+			if structType.Kind() == reflect.Pointer {
+				structType = structType.Elem()
+				if ef.IsNil() {
+					ef = reflect.New(structType)
+				}
+				ef = ef.Elem()
+			}
+			if visited[structType] {
+				continue
+			}
+			visited[structType] = true
+			if err := walkStruct(ef, prefix.nested(tf), visited, fn); err != nil {
+				return err
+			}
+			delete(visited, structType)
+			continue
+		}
+
+		if err := fn(prefix.leaf(tf, ef)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nested returns the prefix for the fields of the given nested struct field.
+func (p fieldPrefix) nested(tf reflect.StructField) fieldPrefix {
+	result := fieldPrefix{
+		path:   p.path,
+		arg:    p.nestedArg(tf),
+		env:    p.nestedEnv(tf),
+		file:   p.file,
+		naming: p.naming,
+	}
+	// Embedded structs keep the promoted field names, just like encoding/json
+	if !tf.Anonymous {
+		result.path = joinPath(p.path, tf.Name)
+	}
+	// The file tag of a struct field names the nested object in the config file
+	if fileName, ok := tf.Tag.Lookup("file"); ok {
+		result.file = appendPath(p.file, fileName)
+	}
+	return result
+}
+
+// leaf returns the description of the given leaf field.
+func (p fieldPrefix) leaf(tf reflect.StructField, ef reflect.Value) field {
+	argName, hasArg := p.argTag(tf)
+	envName, hasEnv := p.envTag(tf)
+	envFileName, hasEnvFile := tf.Tag.Lookup("envFile")
+	fileName, hasFile := tf.Tag.Lookup("file")
+	deprecated, hasDeprecated := tf.Tag.Lookup("deprecated")
+	pos, hasPos := tf.Tag.Lookup("pos")
+	return field{
+		path:          joinPath(p.path, tf.Name),
+		structField:   tf,
+		value:         ef,
+		argName:       argName,
+		hasArg:        hasArg,
+		envName:       envName,
+		hasEnv:        hasEnv,
+		envFileName:   p.env + envFileName,
+		hasEnvFile:    hasEnvFile,
+		secret:        tf.Tag.Get("secret") == "true",
+		filePath:      appendPath(p.file, fileName),
+		hasFile:       hasFile,
+		argAliases:    aliasNames(p.arg, tf.Tag.Get("alias")),
+		envAliases:    aliasNames(p.env, tf.Tag.Get("envAlias")),
+		deprecated:    deprecated,
+		hasDeprecated: hasDeprecated,
+		pos:           pos,
+		hasPos:        hasPos,
+	}
+}
+
+// display returns the display tag of the field.
+// Fields with an envFile tag or secret:"true" hold secrets and default to "length".
+func (f field) display() string {
+	if display, ok := f.structField.Tag.Lookup("display"); ok {
+		return display
+	}
+	if f.hasEnvFile || f.secret {
+		return "length"
+	}
+	return ""
+}
+
+// isNestedStruct reports whether a field of the given type is walked recursively
+// instead of being parsed as a single value.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	// Types like time.Time and libtime.DateTime are parsed from a single string
+	if _, ok := LookupConverter(t); ok || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return false
+	}
+	// Structs without exported fields cannot hold any argument
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// appendPath returns a new slice, so sibling fields never share the backing array.
+func appendPath(prefix []string, name string) []string {
+	result := make([]string, 0, len(prefix)+1)
+	result = append(result, prefix...)
+	return append(result, name)
+}
+
+// nestValues converts dotted keys (e.g. "Kafka.Brokers") into nested maps,
+// so the result can be decoded into nested structs with encoding/json.
+func nestValues(values map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		parts := strings.Split(k, ".")
+		current := result
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := current[part].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				current[part] = next
+			}
+			current = next
+		}
+		current[parts[len(parts)-1]] = v
+	}
+	return result
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bborbe/errors"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// configFileArgName is the command-line argument that points to the config file.
+	configFileArgName = "config"
+	// configFileEnvName is the environment variable that points to the config file.
+	configFileEnvName = "CONFIG_FILE"
+)
+
+// ParseFile parses a JSON or YAML config file into the given struct using file struct tags.
+// Files with the extension .json are decoded as JSON, all other files as YAML.
+// See Parse() documentation for supported types and struct tag options.
+//
+// Parameters:
+//   - ctx: Context for error handling
+//   - data: Pointer to struct with file tags
+//   - path: Path of the config file
+//
+// Returns error if the file cannot be read or parsing fails.
+func ParseFile(ctx context.Context, data interface{}, path string) error {
+	return defaultParser.ParseFile(ctx, data, path)
+}
+
+// hasFileTag reports whether any field of data has a file tag.
+func hasFileTag(data interface{}) bool {
+	var found bool
+	_ = walkFields(data, func(f field) error {
+		found = found || f.hasFile
+		return nil
+	})
+	return found
+}
+
+// configFileArg is the value of the -config argument registered by registerConfigFileArg.
+type configFileArg string
+
+func (c *configFileArg) String() string {
+	return string(*c)
+}
+
+func (c *configFileArg) Set(value string) error {
+	*c = configFileArg(value)
+	return nil
+}
+
+// registerConfigFileArg registers the -config argument if the struct reads from a config file.
+// It returns an error if a field defines the argument, because the field would silently
+// disable reading the config file.
+func registerConfigFileArg(ctx context.Context, flagSet *flag.FlagSet, data interface{}) error {
+	if !hasFileTag(data) {
+		return nil
+	}
+	if existing := flagSet.Lookup(configFileArgName); existing != nil {
+		if other, ok := existing.Value.(*fieldFlag); ok {
+			return errors.Errorf(
+				ctx,
+				"flag %s of field %s is reserved for the config file of structs with file tags",
+				configFileArgName,
+				other.path,
+			)
+		}
+		return errors.Errorf(ctx, "flag %s is already defined", configFileArgName)
+	}
+	flagSet.Var(new(configFileArg), configFileArgName, "path to config file (JSON or YAML)")
+	return nil
+}
+
+// configFilePath returns the config file given by the -config argument or the CONFIG_FILE
+// environment variable. The argument takes precedence. Structs without file tag read no
+// config file.
+func configFilePath(flagSet *flag.FlagSet, data interface{}, environ []string) string {
+	if !hasFileTag(data) {
+		return ""
+	}
+	if f := flagSet.Lookup(configFileArgName); f != nil && f.Value.String() != "" {
+		return f.Value.String()
+	}
+	prefix := configFileEnvName + "="
+	for _, env := range environ {
+		if strings.HasPrefix(env, prefix) {
+			return env[len(prefix):]
+		}
+	}
+	return ""
+}
+
+// fileToValues reads the config file and converts all values of fields with a file tag.
+// Values are converted with the same rules as environment variables, so all types accept
+// the same string syntax as on the command line. Lists are joined with the field separator.
+func fileToValues(
+	ctx context.Context,
+	data interface{},
+	path string,
+	secrets secretResolvers,
+	sources Provenance,
+) (map[string]interface{}, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- path is provided by the operator
+	if err != nil {
+		return nil, errors.Wrapf(ctx, err, "read config file %s failed", path)
+	}
+	document, err := decodeConfigFile(ctx, path, content)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{})
+	if err := walkFields(data, func(f field) error {
+		if !f.hasFile {
+			return nil
+		}
+		raw, ok := lookupFileValue(document, f.filePath)
+		if !ok || raw == nil {
+			return nil
+		}
+		rawString, err := fileValueToString(ctx, f, raw)
+		if err != nil {
+			return err
+		}
+		value, err := secrets.resolve(ctx, f, rawString)
+		if err != nil {
+			return err
+		}
+		if err := stringToValue(ctx, values, f, value); err != nil {
+			return err
+		}
+		sources.add(f, Source{
+			Kind: SourceKindFile,
+			Name: strings.Join(f.filePath, "."),
+			Raw:  rawString,
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func decodeConfigFile(
+	ctx context.Context,
+	path string,
+	content []byte,
+) (map[string]interface{}, error) {
+	document := make(map[string]interface{})
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		if err := decoder.Decode(&document); err != nil {
+			return nil, errors.Wrapf(ctx, err, "decode json config file %s failed", path)
+		}
+		return document, nil
+	}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, errors.Wrapf(ctx, err, "decode yaml config file %s failed", path)
+	}
+	return document, nil
+}
+
+// lookupFileValue follows the key path through nested objects of the config file.
+func lookupFileValue(document map[string]interface{}, path []string) (interface{}, bool) {
+	var current interface{} = document
+	for _, key := range path {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// fileValueToString converts a decoded JSON or YAML value into the string syntax used on the command line.
+func fileValueToString(ctx context.Context, f field, raw interface{}) (string, error) {
+	switch v := raw.(type) {
+	case []interface{}:
+		separator := sliceSeparator(f.structField)
+		parts := make([]string, len(v))
+		for i, elem := range v {
+			part, err := fileValueToString(ctx, f, elem)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = part
+		}
+		return strings.Join(parts, separator), nil
+	case map[interface{}]interface{}:
+		// yaml decodes objects with non-string keys like {1: true}
+		entries := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			entries[fmt.Sprint(key)] = elem
+		}
+		return fileValueToString(ctx, f, entries)
+	case map[string]interface{}:
+		if f.value.Kind() != reflect.Map {
+			return "", errors.Errorf(ctx, "field %s with config file object value is unsupported", f.path)
+		}
+		separator, kvSeparator := mapSeparators(f.structField)
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			part, err := fileValueToString(ctx, f, v[key])
+			if err != nil {
+				return "", err
+			}
+			parts[i] = key + kvSeparator + part
+		}
+		return strings.Join(parts, separator), nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	default:
+		return fmt.Sprintf("%v", v), nil
+	}
+}
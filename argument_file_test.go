@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("ParseFile", func() {
+	type kafka struct {
+		Brokers []TestBroker `file:"brokers" env:"BROKERS" arg:"brokers"`
+		Topic   string       `file:"topic"   env:"TOPIC"   arg:"topic"`
+	}
+	type config struct {
+		Name     string           `file:"name"     env:"NAME"   arg:"name"   default:"default-name"`
+		Port     int              `file:"port"     env:"PORT"   arg:"port"   default:"8080"`
+		Debug    bool             `file:"debug"`
+		Rate     float64          `file:"rate"`
+		Timeout  time.Duration    `file:"timeout"`
+		Interval libtime.Duration `file:"interval"`
+		Tags     []string         `file:"tags"                                                      separator:"|"`
+		Kafka    kafka            `file:"kafka"    env:"KAFKA_" arg:"kafka-"`
+	}
+	var ctx context.Context
+	var dir string
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir = GinkgoT().TempDir()
+	})
+	It("parses yaml file", func() {
+		path := writeFile("config.yaml", `
+name: from-yaml
+port: 9090
+debug: true
+rate: 1.5
+timeout: 1d2h
+interval: 2w
+tags:
+  - a
+  - b
+kafka:
+  brokers:
+    - kafka1:9092
+    - ssl://kafka2:9093
+  topic: orders
+`)
+		var cfg config
+		Expect(argument.ParseFile(ctx, &cfg, path)).To(Succeed())
+		Expect(cfg.Name).To(Equal("from-yaml"))
+		Expect(cfg.Port).To(Equal(9090))
+		Expect(cfg.Debug).To(BeTrue())
+		Expect(cfg.Rate).To(Equal(1.5))
+		Expect(cfg.Timeout).To(Equal(26 * time.Hour))
+		Expect(cfg.Interval).To(Equal(libtime.Duration(14 * 24 * time.Hour)))
+		Expect(cfg.Tags).To(Equal([]string{"a", "b"}))
+		Expect(
+			cfg.Kafka.Brokers,
+		).To(Equal([]TestBroker{"plain://kafka1:9092", "ssl://kafka2:9093"}))
+		Expect(cfg.Kafka.Topic).To(Equal("orders"))
+	})
+	It("parses json file", func() {
+		path := writeFile(
+			"config.json",
+			`{"name":"from-json","port":9091,"tags":["x","y"],"kafka":{"topic":"events"}}`,
+		)
+		var cfg config
+		Expect(argument.ParseFile(ctx, &cfg, path)).To(Succeed())
+		Expect(cfg.Name).To(Equal("from-json"))
+		Expect(cfg.Port).To(Equal(9091))
+		Expect(cfg.Tags).To(Equal([]string{"x", "y"}))
+		Expect(cfg.Kafka.Topic).To(Equal("events"))
+	})
+	It("accepts strings with command-line syntax", func() {
+		path := writeFile("config.yaml", "tags: a|b|c\ntimeout: 90s\n")
+		var cfg config
+		Expect(argument.ParseFile(ctx, &cfg, path)).To(Succeed())
+		Expect(cfg.Tags).To(Equal([]string{"a", "b", "c"}))
+		Expect(cfg.Timeout).To(Equal(90 * time.Second))
+	})
+	It("returns error for missing file", func() {
+		var cfg config
+		err := argument.ParseFile(ctx, &cfg, filepath.Join(dir, "missing.yaml"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("read config file"))
+	})
+	It("returns error for invalid values", func() {
+		path := writeFile("config.yaml", "port: banana\n")
+		var cfg config
+		err := argument.ParseFile(ctx, &cfg, path)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("parse field Port"))
+	})
+	It("returns error for invalid json", func() {
+		path := writeFile("config.json", "{")
+		var cfg config
+		err := argument.ParseFile(ctx, &cfg, path)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("decode json config file"))
+	})
+	Context("Parser", func() {
+		It("reads the file given by -config with precedence default < file < env < args", func() {
+			path := writeFile(
+				"config.yaml",
+				"name: from-file\nport: 9090\nkafka:\n  topic: file-topic\n",
+			)
+			parser := newTestParser(
+				[]string{"-config=" + path, "-kafka-topic=args-topic"},
+				[]string{"PORT=7070", "KAFKA_TOPIC=env-topic"},
+			)
+			var cfg config
+			Expect(parser.Parse(ctx, &cfg)).To(Succeed())
+			Expect(cfg.Name).To(Equal("from-file"))
+			Expect(cfg.Port).To(Equal(7070))
+			Expect(cfg.Kafka.Topic).To(Equal("args-topic"))
+		})
+		It("reads the file given by CONFIG_FILE", func() {
+			path := writeFile("config.json", `{"name":"from-file"}`)
+			parser := newTestParser([]string{}, []string{"CONFIG_FILE=" + path})
+			var cfg config
+			Expect(parser.Parse(ctx, &cfg)).To(Succeed())
+			Expect(cfg.Name).To(Equal("from-file"))
+			Expect(cfg.Port).To(Equal(8080))
+		})
+		It("uses defaults without config file", func() {
+			parser := newTestParser([]string{}, []string{})
+			var cfg config
+			Expect(parser.Parse(ctx, &cfg)).To(Succeed())
+			Expect(cfg.Name).To(Equal("default-name"))
+		})
+		It("does not register -config without file tags", func() {
+			parser := newTestParser([]string{"-config=foo.yaml"}, []string{})
+			var cfg struct {
+				Name string `arg:"name"`
+			}
+			Expect(parser.Parse(ctx, &cfg)).NotTo(Succeed())
+		})
+		It("returns error for a config field in a struct with file tags", func() {
+			path := writeFile("config.yaml", "name: from-file\n")
+			parser := newTestParser([]string{"-config=" + path}, []string{})
+			var cfg struct {
+				Config string `arg:"config"`
+				Name   string `arg:"name"   file:"name"`
+			}
+			err := parser.Parse(ctx, &cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(
+				"flag config of field Config is reserved for the config file of structs with file tags",
+			))
+		})
+		It("ignores CONFIG_FILE without file tags", func() {
+			parser := newTestParser([]string{}, []string{"CONFIG_FILE=/nonexistent.toml"})
+			var cfg struct {
+				Port int `arg:"port" default:"8080"`
+			}
+			Expect(parser.Parse(ctx, &cfg)).To(Succeed())
+			Expect(cfg.Port).To(Equal(8080))
+		})
+		It("does not read a file given by a config field without file tags", func() {
+			path := writeFile("config.toml", "name = \"from-file\"\n")
+			parser := newTestParser([]string{"-config=" + path}, []string{})
+			var cfg struct {
+				Config string `arg:"config"`
+			}
+			Expect(parser.Parse(ctx, &cfg)).To(Succeed())
+			Expect(cfg.Config).To(Equal(path))
+		})
+	})
+})
@@ -9,7 +9,10 @@ import (
 	"context"
 	"encoding"
 	"encoding/json"
+	"net"
+	"net/url"
 	"reflect"
+	"regexp"
 
 	"github.com/bborbe/errors"
 )
@@ -23,6 +26,11 @@ import (
 // For slices of TextMarshaler, each element is converted to a string separately to maintain
 // array structure in JSON, allowing proper unmarshaling into slice types.
 //
+// Keys of nested struct fields use the dotted field path (e.g. "Kafka.Brokers").
+//
+// Values of types registered with RegisterType, url.URL, net.HardwareAddr and regexp.Regexp
+// are set directly without JSON.
+//
 // Parameters:
 //   - ctx: Context for error handling
 //   - data: Pointer to struct to populate
@@ -32,12 +40,21 @@ import (
 func Fill(ctx context.Context, data interface{}, values map[string]interface{}) error {
 	// Convert TextMarshaler types to strings for JSON compatibility
 	jsonValues := make(map[string]interface{}, len(values))
+	directValues := make(map[string]interface{})
 	for k, v := range values {
 		if v == nil {
 			jsonValues[k] = v
 			continue
 		}
 
+		// Registered types and some built-in types do not survive JSON, they are set
+		// after decoding. null still creates the parent objects of nested fields.
+		if isDirectType(reflect.TypeOf(v)) {
+			directValues[k] = v
+			jsonValues[k] = nil
+			continue
+		}
+
 		// If the value implements json.Marshaler, let JSON encoding handle it directly.
 		// This is important for types like UnixTime where MarshalJSON and MarshalText
 		// produce different formats (e.g., integer vs ISO string).
@@ -88,11 +105,52 @@ func Fill(ctx context.Context, data interface{}, values map[string]interface{})
 	}
 
 	buf := &bytes.Buffer{}
-	if err := json.NewEncoder(buf).Encode(jsonValues); err != nil {
+	if err := json.NewEncoder(buf).Encode(nestValues(jsonValues)); err != nil {
 		return errors.Wrap(ctx, err, "encode json failed")
 	}
 	if err := json.NewDecoder(buf).Decode(data); err != nil {
 		return errors.Wrap(ctx, err, "decode json failed")
 	}
-	return nil
+	if len(directValues) == 0 {
+		return nil
+	}
+	return walkFields(data, func(f field) error {
+		if v, ok := directValues[f.path]; ok && f.value.CanSet() {
+			setValue(f.value, reflect.ValueOf(v))
+		}
+		return nil
+	})
+}
+
+// directTypes are the built-in types JSON can not round trip
+// (e.g. the password of a url.URL is lost).
+var directTypes = map[reflect.Type]bool{
+	reflect.TypeOf(url.URL{}):          true,
+	reflect.TypeOf(net.HardwareAddr{}): true,
+	reflect.TypeOf(regexp.Regexp{}):    true,
+}
+
+// isDirectType reports whether t, its element type or its map key type is registered
+// with RegisterType or a direct type.
+func isDirectType(t reflect.Type) bool {
+	if _, ok := registeredConverter(t); ok || directTypes[t] {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice:
+		return isDirectType(t.Elem())
+	case reflect.Map:
+		return isDirectType(t.Key()) || isDirectType(t.Elem())
+	}
+	return false
+}
+
+// setValue sets target to value. Pointer targets get a pointer to a copy of value.
+func setValue(target reflect.Value, value reflect.Value) {
+	if target.Kind() == reflect.Pointer && value.Type() != target.Type() {
+		ptr := reflect.New(target.Type().Elem())
+		ptr.Elem().Set(value)
+		value = ptr
+	}
+	target.Set(value)
 }
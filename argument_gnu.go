@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bborbe/errors"
+)
+
+// WithGNUFlags enables GNU style arguments for Parse, ParseAndPrint, ParseOnly, ParseArgs
+// and Dispatch:
+//
+//   - -p 8080, -p8080 and -p=8080 for fields with short:"p"
+//   - -vq for the bool fields with short:"v" and short:"q", -v=false sets the value
+//   - --no-debug sets the bool field with arg:"debug" to false
+//   - --name value and --name=value for all flags and aliases
+//   - -- ends the flags, all following arguments are left to the caller
+//
+// A single dash always starts short flags, so -port is read as -p -o -r -t.
+// -h and -help still print the usage if no field has short:"h".
+// Struct tags and the priority of args, env, config file and defaults do not change.
+func WithGNUFlags() Option {
+	return func(p *parser) {
+		p.gnu = true
+	}
+}
+
+// shortFlags returns the flag name of every field with a short tag by the short name.
+func shortFlags(
+	ctx context.Context,
+	data interface{},
+	options fieldOptions,
+) (map[string]string, error) {
+	result := make(map[string]string)
+	paths := make(map[string]string)
+	if err := walkFieldsWith(data, options, func(f field) error {
+		short, ok := f.structField.Tag.Lookup("short")
+		if !ok || !f.hasArg {
+			return nil
+		}
+		if utf8.RuneCountInString(short) != 1 || short == "-" || short == "=" {
+			return errors.Errorf(
+				ctx,
+				"short tag %q of field %s must be a single character",
+				short,
+				f.path,
+			)
+		}
+		if other, ok := paths[short]; ok {
+			return errors.Errorf(
+				ctx,
+				"short tag %q of field %s is already used by field %s",
+				short,
+				f.path,
+				other,
+			)
+		}
+		result[short] = f.argName
+		paths[short] = f.path
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// gnuArgs translates GNU style arguments into arguments the flag package understands.
+// Short flags become --name=value, --no-name becomes --name=false for bool flags.
+// Translation stops at -- and at the first argument that is not a flag, just like the flag
+// package stops parsing there.
+func gnuArgs(
+	ctx context.Context,
+	flagSet *flag.FlagSet,
+	shorts map[string]string,
+	args []string,
+) ([]string, error) {
+	result := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--" || arg == "-" || !strings.HasPrefix(arg, "-"):
+			return append(result, args[i:]...), nil
+		case strings.HasPrefix(arg, "--"):
+			name, _, hasValue := strings.Cut(arg[2:], "=")
+			if negated, ok := strings.CutPrefix(name, "no-"); ok && !hasValue &&
+				flagSet.Lookup(name) == nil && isBoolFlag(flagSet, negated) {
+				result = append(result, "--"+negated+"=false")
+				continue
+			}
+			result = append(result, arg)
+			// The value of --name value may start with a dash and is never translated
+			if !hasValue && flagSet.Lookup(name) != nil && !isBoolFlag(flagSet, name) &&
+				i+1 < len(args) {
+				i++
+				result = append(result, args[i])
+			}
+		case (arg == "-h" || arg == "-help") && shorts["h"] == "":
+			result = append(result, arg)
+		default:
+			letters := []rune(arg[1:])
+			for j, letter := range letters {
+				name, ok := shorts[string(letter)]
+				if !ok {
+					return nil, errors.Errorf(
+						ctx,
+						"unknown short flag -%c in %s",
+						letter,
+						arg,
+					)
+				}
+				if isBoolFlag(flagSet, name) {
+					// -v=false passes the value, the flag package rejects invalid bools
+					if j+1 < len(letters) && letters[j+1] == '=' {
+						result = append(result, "--"+name+"="+string(letters[j+2:]))
+						break
+					}
+					result = append(result, "--"+name)
+					continue
+				}
+				value := strings.TrimPrefix(string(letters[j+1:]), "=")
+				if j+1 == len(letters) {
+					if i+1 == len(args) {
+						return nil, errors.Errorf(ctx, "short flag -%c needs an argument", letter)
+					}
+					i++
+					value = args[i]
+				}
+				result = append(result, "--"+name+"="+value)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// isBoolFlag reports whether the flag with the given name takes no value.
+func isBoolFlag(flagSet *flag.FlagSet, name string) bool {
+	f := flagSet.Lookup(name)
+	if f == nil {
+		return false
+	}
+	boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && boolFlag.IsBoolFlag()
+}
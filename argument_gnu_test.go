@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("GNU flags", func() {
+	type config struct {
+		Port    int           `arg:"port"    env:"PORT" short:"p" default:"8080"`
+		Verbose bool          `arg:"verbose"            short:"v"`
+		Quiet   bool          `arg:"quiet"              short:"q"`
+		Color   bool          `arg:"color"                        default:"true"`
+		Name    string        `arg:"name"               short:"n"                alias:"title"`
+		Timeout time.Duration `arg:"timeout"`
+	}
+	var ctx context.Context
+	var output *bytes.Buffer
+	var cfg config
+	var options []argument.Option
+	BeforeEach(func() {
+		ctx = context.Background()
+		output = &bytes.Buffer{}
+		options = []argument.Option{argument.WithGNUFlags(), argument.WithOutput(output)}
+		cfg = config{}
+	})
+	DescribeTable("parses",
+		func(args []string, expected config) {
+			Expect(newTestParser(args, nil, options...).ParseOnly(ctx, &cfg)).To(Succeed())
+			Expect(cfg).To(Equal(expected))
+		},
+		Entry("long flags with value", []string{"--port=9090", "--name", "--dash"},
+			config{Port: 9090, Color: true, Name: "--dash"}),
+		Entry("short flag with separate value", []string{"-p", "9090"},
+			config{Port: 9090, Color: true}),
+		Entry("short flag with attached value", []string{"-p9090", "-n=ben"},
+			config{Port: 9090, Color: true, Name: "ben"}),
+		Entry("combined bool flags", []string{"-vq"},
+			config{Port: 8080, Verbose: true, Quiet: true, Color: true}),
+		Entry("combined bool flags followed by a value flag", []string{"-vqp", "9090"},
+			config{Port: 9090, Verbose: true, Quiet: true, Color: true}),
+		Entry("bool short flags with value", []string{"-v=false", "-q=true"},
+			config{Port: 8080, Quiet: true, Color: true}),
+		Entry("combined bool flags with value", []string{"-vq=false"},
+			config{Port: 8080, Verbose: true, Color: true}),
+		Entry("negated bool flags", []string{"--no-color", "--no-verbose"},
+			config{Port: 8080}),
+		Entry("aliases", []string{"--title", "ben"},
+			config{Port: 8080, Color: true, Name: "ben"}),
+		Entry("values starting with a dash", []string{"--timeout", "-1s", "-n", "-x"},
+			config{Port: 8080, Color: true, Name: "-x", Timeout: -time.Second}),
+	)
+	It("stops at the terminator", func() {
+		parser := argument.NewParser(
+			argument.WithGNUFlags(),
+			argument.WithOutput(output),
+		)
+		Expect(parser.ParseArgs(ctx, &cfg, []string{"-v", "--", "-q", "file"})).To(Succeed())
+		Expect(cfg.Verbose).To(BeTrue())
+		Expect(cfg.Quiet).To(BeFalse())
+	})
+	It("keeps the priority of args over env", func() {
+		Expect(
+			newTestParser([]string{"-p", "1"}, []string{"PORT=2"}, options...).ParseOnly(ctx, &cfg),
+		).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+		Expect(
+			newTestParser(nil, []string{"PORT=2"}, options...).ParseOnly(ctx, &cfg),
+		).To(Succeed())
+		Expect(cfg.Port).To(Equal(2))
+	})
+	It("returns error for unknown short flags", func() {
+		err := newTestParser([]string{"-vx"}, nil, options...).ParseOnly(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown short flag -x in -vx"))
+	})
+	It("returns error for invalid bool short flag values", func() {
+		err := newTestParser([]string{"-v=banana"}, nil, options...).ParseOnly(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`invalid boolean value "banana" for -verbose`))
+	})
+	It("returns error for short flags without value", func() {
+		err := newTestParser([]string{"-p"}, nil, options...).ParseOnly(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("short flag -p needs an argument"))
+	})
+	It("returns error for duplicate short tags", func() {
+		var invalid struct {
+			A bool `arg:"a" short:"x"`
+			B bool `arg:"b" short:"x"`
+		}
+		err := newTestParser(nil, nil, options...).ParseOnly(ctx, &invalid)
+		Expect(err).To(HaveOccurred())
+		Expect(
+			err.Error(),
+		).To(ContainSubstring(`short tag "x" of field B is already used by field A`))
+	})
+	It("prints the usage for -h", func() {
+		err := newTestParser([]string{"-h"}, nil, options...).ParseOnly(ctx, &cfg)
+		Expect(errors.Is(err, argument.ErrHelp)).To(BeTrue())
+		Expect(output.String()).To(ContainSubstring("-p, --port"))
+	})
+	It("leaves the Go style untouched without WithGNUFlags", func() {
+		parser := newTestParser([]string{"-port=9090", "-verbose"}, nil)
+		Expect(parser.ParseOnly(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(9090))
+		Expect(cfg.Verbose).To(BeTrue())
+	})
+})
@@ -393,3 +393,47 @@ var _ = Describe("HasValidation", func() {
 		})
 	})
 })
+
+var _ = Describe("HasValidation nested structs", func() {
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+	It("validates nested struct implementing HasValidation", func() {
+		config := &struct {
+			Server testValidatingConfig
+		}{
+			Server: testValidatingConfig{Port: 8080},
+		}
+		Expect(argument.ValidateHasValidation(ctx, config)).To(Succeed())
+
+		config.Server.Port = 80
+		err := argument.ValidateHasValidation(ctx, config)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("field Server"))
+		Expect(err.Error()).To(ContainSubstring("port must be >= 1024"))
+	})
+	It("validates fields of nested structs with their field path", func() {
+		type kafka struct {
+			Brokers testBrokers
+		}
+		config := &struct {
+			Kafka *kafka
+		}{
+			Kafka: &kafka{Brokers: testBrokers{}},
+		}
+		err := argument.ValidateHasValidation(ctx, config)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("field Kafka.Brokers"))
+		Expect(err.Error()).To(ContainSubstring("at least one broker required"))
+	})
+	It("skips nil nested struct pointers", func() {
+		type kafka struct {
+			Brokers testBrokers
+		}
+		config := &struct {
+			Kafka *kafka
+		}{}
+		Expect(argument.ValidateHasValidation(ctx, config)).To(Succeed())
+	})
+})
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+// mapSeparators returns the separator between entries and the separator between key and value
+// of a map field. Defaults to "," and "=".
+func mapSeparators(tf reflect.StructField) (string, string) {
+	separator := tf.Tag.Get("separator")
+	if separator == "" {
+		separator = ","
+	}
+	kvSeparator := tf.Tag.Get("kvSeparator")
+	if kvSeparator == "" {
+		kvSeparator = "="
+	}
+	return separator, kvSeparator
+}
+
+// parseMapFromString splits a string like "a=1,b=2" into entries and converts each key and value
+// to the key and element type of mapType. Whitespace around keys and values is trimmed.
+func parseMapFromString(
+	ctx context.Context,
+	value string,
+	separator string,
+	kvSeparator string,
+	mapType reflect.Type,
+) (interface{}, error) {
+	result := reflect.MakeMap(mapType)
+	if err := mergeMapFromString(ctx, result, value, separator, kvSeparator); err != nil {
+		return nil, err
+	}
+	return result.Interface(), nil
+}
+
+// mergeMapFromString adds all entries of value to the given map.
+// Later entries override earlier entries with the same key.
+func mergeMapFromString(
+	ctx context.Context,
+	result reflect.Value,
+	value string,
+	separator string,
+	kvSeparator string,
+) error {
+	for _, part := range strings.Split(value, separator) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, v, found := strings.Cut(part, kvSeparator)
+		if !found {
+			return errors.Errorf(
+				ctx,
+				"invalid map entry %q, expected key%svalue",
+				part,
+				kvSeparator,
+			)
+		}
+		key, err := parseMapElement(ctx, strings.TrimSpace(k), result.Type().Key())
+		if err != nil {
+			return errors.Wrapf(ctx, err, "parse key of map entry %q failed", part)
+		}
+		elem, err := parseMapElement(ctx, strings.TrimSpace(v), result.Type().Elem())
+		if err != nil {
+			return errors.Wrapf(ctx, err, "parse value of map entry %q failed", part)
+		}
+		result.SetMapIndex(key, elem)
+	}
+	return nil
+}
+
+// parseMapElement converts a single map key or value with the converter of its type.
+func parseMapElement(ctx context.Context, value string, t reflect.Type) (reflect.Value, error) {
+	if !isScalarType(t) {
+		return reflect.Value{}, errors.Errorf(ctx, "unsupported map element type: %v", t)
+	}
+	result, err := convert(ctx, value, t)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return result.Convert(t), nil
+}
+
+// mapEntries formats all entries of a map as "key=value" sorted by key.
+func mapEntries(value interface{}) []string {
+	rv := reflect.ValueOf(value)
+	keys := make([]string, 0, rv.Len())
+	entries := make(map[string]string, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := fmt.Sprintf("%v", iter.Key().Interface())
+		keys = append(keys, key)
+		entries[key] = fmt.Sprintf("%s=%v", key, iter.Value().Interface())
+	}
+	sort.Strings(keys)
+	result := make([]string, len(keys))
+	for i, key := range keys {
+		result[i] = entries[key]
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("Map fields", func() {
+	type labels map[string]string
+	type config struct {
+		Labels   labels                   `arg:"labels"   env:"LABELS"   default:"team=core"`
+		Limits   map[string]int           `arg:"limits"   env:"LIMITS"                       separator:";" kvSeparator:":"`
+		Timeouts map[string]time.Duration `arg:"timeouts" env:"TIMEOUTS"`
+		Brokers  map[TestBroker]TestURL   `arg:"brokers"  env:"BROKERS"`
+		Enabled  map[int]bool             `arg:"enabled"  env:"ENABLED"`
+	}
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+	It("uses the default", func() {
+		var cfg config
+		Expect(newTestParser([]string{}, []string{}).Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Labels).To(Equal(labels{"team": "core"}))
+		Expect(cfg.Limits).To(BeNil())
+	})
+	It("parses args with custom separators", func() {
+		var cfg config
+		Expect(
+			newTestParser([]string{"-limits=a:1; b:2"}, []string{}).Parse(ctx, &cfg),
+		).To(Succeed())
+		Expect(cfg.Limits).To(Equal(map[string]int{"a": 1, "b": 2}))
+	})
+	It("merges repeated flags and replaces the default", func() {
+		var cfg config
+		Expect(newTestParser(
+			[]string{"-labels=env=prod,tier=web", "-labels=tier=api", "-labels=zone=eu"},
+			[]string{},
+		).Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Labels).To(Equal(labels{"env": "prod", "tier": "api", "zone": "eu"}))
+	})
+	It("parses env", func() {
+		var cfg config
+		Expect(newTestParser([]string{}, []string{
+			"LABELS=env=dev",
+			"TIMEOUTS=read=1d,write=30s",
+			"ENABLED=1=true,2=false",
+		}).Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Labels).To(Equal(labels{"env": "dev"}))
+		Expect(cfg.Timeouts).To(Equal(map[string]time.Duration{
+			"read":  24 * time.Hour,
+			"write": 30 * time.Second,
+		}))
+		Expect(cfg.Enabled).To(Equal(map[int]bool{1: true, 2: false}))
+	})
+	It("converts TextUnmarshaler keys and values", func() {
+		var cfg config
+		Expect(newTestParser(
+			[]string{"-brokers=kafka1:9092=https://a.example.com"},
+			[]string{},
+		).Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Brokers).To(Equal(map[TestBroker]TestURL{
+			"plain://kafka1:9092": "https://a.example.com",
+		}))
+	})
+	It("returns error for entry without key/value separator", func() {
+		var cfg config
+		err := newTestParser([]string{}, []string{"LABELS=banana"}).Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`invalid map entry "banana"`))
+	})
+	It("returns error for invalid value", func() {
+		var cfg config
+		err := newTestParser([]string{"-limits=a:x"}, []string{}).Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`parse value of map entry "a:x" failed`))
+	})
+	It("returns error for invalid TextUnmarshaler value", func() {
+		var cfg config
+		err := newTestParser([]string{}, []string{"BROKERS=kafka:9092=ftp://x"}).Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+	})
+	It("validates required maps", func() {
+		cfg := struct {
+			Labels map[string]string `arg:"labels" required:"true"`
+		}{
+			Labels: map[string]string{},
+		}
+		err := argument.ValidateRequired(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Required field empty, define parameter labels"))
+	})
+	It("reads objects from the config file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		content := "labels:\n  env: prod\n  tier: web\nlimits:\n  a: 1\n"
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		var cfg struct {
+			Labels labels         `file:"labels"`
+			Limits map[string]int `file:"limits" separator:";" kvSeparator:":"`
+		}
+		Expect(argument.ParseFile(ctx, &cfg, path)).To(Succeed())
+		Expect(cfg.Labels).To(Equal(labels{"env": "prod", "tier": "web"}))
+		Expect(cfg.Limits).To(Equal(map[string]int{"a": 1}))
+	})
+	It("reads objects with non-string keys from the config file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		content := "limits: {1: true, 2: false}\nweights:\n  1.5: a\n"
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		var cfg struct {
+			Limits  map[int]bool      `file:"limits"`
+			Weights map[string]string `file:"weights"`
+		}
+		Expect(argument.ParseFile(ctx, &cfg, path)).To(Succeed())
+		Expect(cfg.Limits).To(Equal(map[int]bool{1: true, 2: false}))
+		Expect(cfg.Weights).To(Equal(map[string]string{"1.5": "a"}))
+	})
+	It("prints sorted keys", func() {
+		buf := &bytes.Buffer{}
+		log.SetOutput(buf)
+		log.SetFlags(0)
+		cfg := struct {
+			Labels map[string]string
+			Empty  map[string]string
+		}{
+			Labels: map[string]string{"zone": "eu", "env": "prod", "app": "web"},
+		}
+		Expect(argument.Print(ctx, &cfg)).To(Succeed())
+		Expect(buf.String()).To(Equal(`Argument: Labels {3}: app=web, env=prod, zone=eu
+Argument: Empty {}
+`))
+	})
+	It("shows separators in usage", func() {
+		buf := &bytes.Buffer{}
+		var cfg config
+		Expect(argument.Usage(buf, &cfg)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(`map[string]int (sep ";", kv ":")`))
+	})
+})
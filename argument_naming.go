@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Naming is the strategy that derives flag names from Go field names, see WithNaming.
+// Env names are always upper case words joined by "_" (e.g. KAFKA_BROKERS).
+type Naming string
+
+const (
+	// NamingKebab derives kafka-brokers from KafkaBrokers.
+	NamingKebab Naming = "kebab"
+	// NamingSnake derives kafka_brokers from KafkaBrokers.
+	NamingSnake Naming = "snake"
+	// NamingCamel derives kafkaBrokers from KafkaBrokers.
+	NamingCamel Naming = "camel"
+)
+
+// autoName is the arg and env tag value that derives the name from the Go field name.
+const autoName = "auto"
+
+// skipName is the arg and env tag value that opts a field out of the flag or env var.
+const skipName = "-"
+
+// WithNaming derives the flag and env names of fields without arg or env tag from their
+// Go field name (e.g. KafkaBrokers becomes -kafka-brokers and KAFKA_BROKERS with NamingKebab).
+// Nested structs without tag become prefixes (-kafka-brokers for Kafka.Brokers), embedded
+// structs do not. arg:"-" opts a field out of both, unless it has an explicit env tag, and
+// env:"-" opts it out of the env var only. Positional fields get no flag.
+//
+// Without WithNaming only arg:"auto" and env:"auto" derive names, flags use NamingKebab.
+func WithNaming(naming Naming) Option {
+	return func(p *parser) {
+		p.naming = naming
+	}
+}
+
+// argTag returns the flag name of the leaf field tf including the prefix of parent structs.
+func (p fieldPrefix) argTag(tf reflect.StructField) (string, bool) {
+	name, ok := tf.Tag.Lookup("arg")
+	_, hasPos := tf.Tag.Lookup("pos")
+	switch {
+	case name == skipName:
+		return "", false
+	case name == autoName || !ok && p.naming != "" && !hasPos:
+		return p.naming.join(p.arg, tf.Name), true
+	}
+	return p.arg + name, ok
+}
+
+// envTag returns the env name of the leaf field tf including the prefix of parent structs.
+// Fields with arg:"-" get no derived env name, only an explicit env tag.
+func (p fieldPrefix) envTag(tf reflect.StructField) (string, bool) {
+	name, ok := tf.Tag.Lookup("env")
+	switch {
+	case name == skipName:
+		return "", false
+	case name == autoName || !ok && p.naming != "" && tf.Tag.Get("arg") != skipName:
+		return p.env + envWords(tf.Name), true
+	}
+	return p.env + name, ok
+}
+
+// nestedArg returns the flag prefix for the fields of the nested struct field tf.
+func (p fieldPrefix) nestedArg(tf reflect.StructField) string {
+	name, ok := tf.Tag.Lookup("arg")
+	switch {
+	case name == skipName:
+		return p.arg
+	case name == autoName || !ok && p.naming != "" && !tf.Anonymous:
+		return p.naming.join(p.arg, tf.Name) + p.naming.separator()
+	}
+	return p.arg + name
+}
+
+// nestedEnv returns the env prefix for the fields of the nested struct field tf.
+func (p fieldPrefix) nestedEnv(tf reflect.StructField) string {
+	name, ok := tf.Tag.Lookup("env")
+	switch {
+	case name == skipName:
+		return p.env
+	case name == autoName || !ok && p.naming != "" && !tf.Anonymous:
+		return p.env + envWords(tf.Name) + "_"
+	}
+	return p.env + name
+}
+
+// join appends the flag name derived from fieldName to prefix.
+// Camel case names start upper case after a prefix ending with a letter or digit.
+func (n Naming) join(prefix string, fieldName string) string {
+	words := splitWords(fieldName)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	switch n {
+	case NamingSnake:
+		return prefix + strings.Join(words, "_")
+	case NamingCamel:
+		last, _ := utf8.DecodeLastRuneInString(prefix)
+		for i, word := range words {
+			if i > 0 || unicode.IsLetter(last) || unicode.IsDigit(last) {
+				words[i] = strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+		return prefix + strings.Join(words, "")
+	}
+	return prefix + strings.Join(words, "-")
+}
+
+// separator returns the separator between the prefix of a nested struct and its fields.
+func (n Naming) separator() string {
+	switch n {
+	case NamingSnake:
+		return "_"
+	case NamingCamel:
+		return ""
+	}
+	return "-"
+}
+
+// envWords returns the env name derived from fieldName (e.g. KAFKA_BROKERS).
+func envWords(fieldName string) string {
+	return strings.ToUpper(strings.Join(splitWords(fieldName), "_"))
+}
+
+// splitWords splits a Go identifier into words at case changes, keeping acronyms and
+// trailing digits together (e.g. HTTPServer2Addr becomes HTTP, Server2, Addr).
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		previous := runes[i-1]
+		nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(previous) || unicode.IsDigit(previous) ||
+			unicode.IsUpper(previous) && nextIsLower {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
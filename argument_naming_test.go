@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("Naming", func() {
+	type server struct {
+		HTTPAddr string
+	}
+	type config struct {
+		KafkaBrokers string `required:"true"`
+		Port         int    `                arg:"listen" env:"LISTEN"`
+		Internal     string `                arg:"-"      env:"-"`
+		Server       server
+		Src          string `                                          pos:"0"`
+	}
+	var ctx context.Context
+	var cfg config
+	BeforeEach(func() {
+		ctx = context.Background()
+		cfg = config{}
+	})
+	It("derives kebab case flags and env names", func() {
+		err := newTestParser(
+			[]string{"-kafka-brokers", "kafka:9092", "-server-http-addr", ":8080", "input"},
+			[]string{"LISTEN=9090", "INTERNAL=secret"},
+			argument.WithNaming(argument.NamingKebab),
+		).Parse(ctx, &cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg).To(Equal(config{
+			KafkaBrokers: "kafka:9092",
+			Port:         9090,
+			Server:       server{HTTPAddr: ":8080"},
+			Src:          "input",
+		}))
+	})
+	It("derives env names", func() {
+		_, err := newTestParser(
+			nil,
+			[]string{"KAFKA_BROKERS=kafka:9092", "SERVER_HTTP_ADDR=:8080"},
+			argument.WithNaming(argument.NamingKebab),
+			argument.WithEnvPrefix("ORDERS_"),
+		).ParseWithReport(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("define env ORDERS_KAFKA_BROKERS"))
+
+		provenance, err := newTestParser(
+			nil,
+			[]string{"ORDERS_KAFKA_BROKERS=kafka:9092", "ORDERS_SERVER_HTTP_ADDR=:8080"},
+			argument.WithNaming(argument.NamingKebab),
+			argument.WithEnvPrefix("ORDERS_"),
+		).ParseWithReport(ctx, &cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Server.HTTPAddr).To(Equal(":8080"))
+		Expect(provenance["KafkaBrokers"].String()).To(Equal("env ORDERS_KAFKA_BROKERS"))
+	})
+	DescribeTable("derives flags with the naming",
+		func(naming argument.Naming, brokers string, addr string) {
+			err := newTestParser(
+				[]string{"-" + brokers, "kafka:9092", "-" + addr, ":8080"},
+				nil,
+				argument.WithNaming(naming),
+			).Parse(ctx, &cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.KafkaBrokers).To(Equal("kafka:9092"))
+			Expect(cfg.Server.HTTPAddr).To(Equal(":8080"))
+		},
+		Entry("kebab", argument.NamingKebab, "kafka-brokers", "server-http-addr"),
+		Entry("snake", argument.NamingSnake, "kafka_brokers", "server_http_addr"),
+		Entry("camel", argument.NamingCamel, "kafkaBrokers", "serverHttpAddr"),
+	)
+	It("reports the derived names for required fields", func() {
+		err := newTestParser(nil, nil, argument.WithNaming(argument.NamingKebab)).Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"Required field empty, define parameter kafka-brokers or define env KAFKA_BROKERS",
+		))
+		var validationErrors argument.ValidationErrors
+		Expect(errors.As(err, &validationErrors)).To(BeTrue())
+		Expect(validationErrors[0].Arg).To(Equal("kafka-brokers"))
+	})
+	It("shows the derived names in usage", func() {
+		output := &bytes.Buffer{}
+		err := newTestParser(
+			[]string{"-h"},
+			nil,
+			argument.WithNaming(argument.NamingKebab),
+			argument.WithOutput(output),
+		).ParseOnly(ctx, &cfg)
+		Expect(errors.Is(err, argument.ErrHelp)).To(BeTrue())
+		Expect(output.String()).To(ContainSubstring("-kafka-brokers"))
+		Expect(output.String()).To(ContainSubstring("KAFKA_BROKERS"))
+		Expect(output.String()).To(ContainSubstring("-server-http-addr"))
+		Expect(output.String()).NotTo(ContainSubstring("internal"))
+		Expect(output.String()).NotTo(ContainSubstring("-src"))
+	})
+	It("does not derive names without WithNaming", func() {
+		err := newTestParser([]string{"-kafka-brokers", "kafka:9092"}, nil).Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("flag provided but not defined: -kafka-brokers"))
+	})
+	It("derives names of auto tags without WithNaming", func() {
+		type autoConfig struct {
+			KafkaBrokers string `arg:"auto" env:"auto"`
+			Port         int    `arg:"port"`
+		}
+		var autoCfg autoConfig
+		Expect(newTestParser(
+			[]string{"-kafka-brokers", "kafka:9092"},
+			[]string{"PORT=1"},
+		).Parse(ctx, &autoCfg)).To(Succeed())
+		Expect(autoCfg).To(Equal(autoConfig{KafkaBrokers: "kafka:9092"}))
+
+		autoCfg = autoConfig{}
+		Expect(newTestParser(nil, []string{"KAFKA_BROKERS=kafka:9092"}).
+			Parse(ctx, &autoCfg)).To(Succeed())
+		Expect(autoCfg.KafkaBrokers).To(Equal("kafka:9092"))
+	})
+	It("uses the naming for auto tags", func() {
+		type autoConfig struct {
+			KafkaBrokers string `arg:"auto"`
+		}
+		var autoCfg autoConfig
+		Expect(newTestParser(
+			[]string{"-kafka_brokers", "kafka:9092"},
+			nil,
+			argument.WithNaming(argument.NamingSnake),
+		).Parse(ctx, &autoCfg)).To(Succeed())
+		Expect(autoCfg.KafkaBrokers).To(Equal("kafka:9092"))
+	})
+	It("skips fields with arg:\"-\"", func() {
+		err := newTestParser(
+			[]string{"-internal", "x"},
+			nil,
+			argument.WithNaming(argument.NamingKebab),
+		).Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("flag provided but not defined: -internal"))
+	})
+	It("returns error for colliding derived names", func() {
+		type kafka struct {
+			Brokers string
+		}
+		type clashConfig struct {
+			KafkaBrokers string
+			Kafka        kafka
+		}
+		var clashCfg clashConfig
+		err := newTestParser(
+			nil,
+			nil,
+			argument.WithNaming(argument.NamingKebab),
+		).Parse(ctx, &clashCfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"flag kafka-brokers of field Kafka.Brokers is already used by field KafkaBrokers",
+		))
+	})
+	It("skips the derived env var of fields with arg:\"-\"", func() {
+		type skipConfig struct {
+			Skip  string `arg:"-"`
+			Token string `arg:"-" env:"API_TOKEN"`
+		}
+		var skipCfg skipConfig
+		Expect(newTestParser(
+			nil,
+			[]string{"ORDERS_SKIP=1", "ORDERS_API_TOKEN=secret"},
+			argument.WithNaming(argument.NamingKebab),
+			argument.WithEnvPrefix("ORDERS_"),
+		).Parse(ctx, &skipCfg)).To(Succeed())
+		Expect(skipCfg).To(Equal(skipConfig{Token: "secret"}))
+	})
+	It("keeps embedded structs without prefix", func() {
+		type Embedded struct {
+			LogLevel string
+		}
+		type embeddedConfig struct {
+			Embedded
+			Name string
+		}
+		var embeddedCfg embeddedConfig
+		Expect(newTestParser(
+			[]string{"-log-level", "debug"},
+			[]string{"NAME=orders"},
+			argument.WithNaming(argument.NamingKebab),
+		).Parse(ctx, &embeddedCfg)).To(Succeed())
+		Expect(embeddedCfg.LogLevel).To(Equal("debug"))
+		Expect(embeddedCfg.Name).To(Equal("orders"))
+	})
+})
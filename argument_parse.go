@@ -6,23 +6,27 @@ package argument
 
 import (
 	"context"
-	"os"
-
-	"github.com/bborbe/errors"
 )
 
 // Parse combines all functionality. It parses command-line arguments and environment variables
 // into a struct using struct tags, then validates required fields are set.
 //
 // Supported Types:
-//   - Basic types: string, bool, int, int32, int64, uint, uint64, float64
-//   - Pointer types: *float64 (optional values, nil if not provided)
-//   - Slice types: []string, []int, []int64, []uint, []uint64, []float64, []bool
+//   - Basic types: string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+//     float32, float64; integers accept the base prefixes 0x, 0o, 0b and 0 like the flag package
+//     (e.g. 0x10 is 16); out of range values return an *OverflowError; an empty argument
+//     (e.g. -port=) is a parse error for bools and numbers
+//   - Pointer types: *string, *int8, *float32, ... (optional values, nil if not provided)
+//   - Slice types: []string, []int, []uint8, []float32, []bool, ... of all basic types
 //   - Custom type slices: []Username where type Username string
 //   - Custom types implementing encoding.TextUnmarshaler: For complex parsing logic
+//   - Types registered with RegisterType: For types of other packages
 //   - Standard library time types:
 //   - time.Time and *time.Time: RFC3339 format (e.g., "2006-01-02T15:04:05Z")
 //   - time.Duration and *time.Duration: Extended format supporting days (e.g., "1d2h30m", "7d")
+//   - Standard library network types: net.IP, net.HardwareAddr, netip.Addr, netip.Prefix,
+//     netip.AddrPort, *url.URL (Print hides the password) and *regexp.Regexp, including slices
+//     of values and pointers (e.g. []*url.URL)
 //   - github.com/bborbe/time types:
 //   - libtime.Duration and *libtime.Duration: Extended duration with weeks (e.g., "2w", "1w3d")
 //   - libtime.DateTime and *libtime.DateTime: Timestamp with timezone
@@ -36,12 +40,33 @@ import (
 // Whitespace around each element is automatically trimmed.
 // Use the separator tag to customize the delimiter (e.g., separator:":").
 //
+// Map types take key=value pairs (e.g., "env=prod,tier=web"). Use the kvSeparator tag to
+// customize the delimiter between key and value. Repeated flags add entries to the map.
+//
 // Struct Tags:
-//   - arg: Command-line argument name (required to parse field)
-//   - env: Environment variable name (optional)
+//   - arg: Command-line argument name (required to parse field, unless WithNaming is used);
+//     "auto" derives the name from the field name, "-" skips the flag and the derived env name
+//   - env: Environment variable name (optional); prefixed by WithEnvPrefix or HasEnvPrefix;
+//     "auto" and "-" work like for arg
+//   - envFile: Environment variable with the path of a file holding the value, used if the env
+//     var is unset; trailing newlines are trimmed and Print shows only the length by default
+//     (optional)
+//   - file: Key in the JSON or YAML config file (optional)
 //   - default: Default value if not provided (optional)
-//   - separator: Separator for slice values (default: ",", optional)
+//   - separator: Separator for slice values and map entries (default: ",", optional)
+//   - kvSeparator: Separator between key and value of map entries (default: "=", optional)
+//   - repeat: "true" lets every occurrence of a slice flag append instead of replace (optional)
+//   - secret: "true" resolves references like file:///run/secrets/db or env://NAME before
+//     conversion, see WithSecretResolver; Print shows only the length by default (optional)
+//   - alias, envAlias: Additional flag and env names separated by "," (optional)
+//   - deprecated: Log a warning with this message if an alias, or the field without aliases,
+//     is used; see WithLogger (optional)
+//   - short: Single character short flag used with WithGNUFlags (e.g. short:"p" for -p 8080) (optional)
+//   - pos: Bind the positional argument with this index (starting at 0) or, with "rest", all
+//     remaining arguments into a slice (optional)
 //   - required: Mark field as required (optional)
+//   - min, max, len, oneof, pattern, nonempty: Validate the value, see ValidateHasValidation (optional)
+//   - requiredIf, requiredWith, excludes, exclusive: Constraints between fields, see ValidateRequired (optional)
 //   - display: Control how value is displayed - "length" shows only length for sensitive data (optional)
 //   - usage: Help text for the argument (optional)
 //
@@ -57,6 +82,17 @@ import (
 //	    Ports    []int         `arg:"ports" env:"PORTS" separator:":" usage:"Port numbers"`
 //	}
 //
+// Nested structs (and *struct) group related settings. The arg and env tags of a struct
+// field act as prefixes for the names of its fields:
+//
+//	type KafkaConfig struct {
+//	    Brokers []string `arg:"brokers" env:"BROKERS"`
+//	}
+//
+//	type Config struct {
+//	    Kafka KafkaConfig `arg:"kafka-" env:"KAFKA_"` // -kafka-brokers and KAFKA_BROKERS
+//	}
+//
 // Custom types can implement encoding.TextUnmarshaler for specialized parsing:
 //
 //	type Broker string
@@ -75,18 +111,25 @@ import (
 //	    Brokers []Broker `arg:"brokers" env:"BROKERS" usage:"Kafka brokers"`
 //	}
 //
-// Precedence: Command-line arguments override environment variables, which override defaults.
+// Config file: If any field has a file tag, Parse reads the JSON or YAML file given by the
+// -config argument or the CONFIG_FILE environment variable. Such a struct must not define the
+// -config argument itself (e.g. with arg:"config"), Parse returns an error for it. File values
+// accept the same string syntax as the command line; lists are joined with the field separator.
+//
+// Precedence: Command-line arguments override environment variables, which override the
+// config file, which overrides defaults.
+//
+// Validation: After parsing, Parse runs Validate, which reports every empty required field
+// and every failing HasValidation together as ValidationErrors instead of stopping at the first.
+//
+// Help: -h and -help print the Usage table of data to the flag output. flag.CommandLine uses
+// flag.ExitOnError, so Parse exits the process with status 0 there; a Parser from NewParser
+// returns an error matching ErrHelp instead.
+//
+// Parse registers flags on flag.CommandLine and reads os.Args and os.Environ.
+// Use NewParser for an isolated Parser that can be called more than once per process.
 func Parse(ctx context.Context, data interface{}) error {
-	if err := ParseOnly(ctx, data); err != nil {
-		return errors.Wrap(ctx, err, "parse failed")
-	}
-	if err := ValidateRequired(ctx, data); err != nil {
-		return errors.Wrap(ctx, err, "validate required failed")
-	}
-	if err := ValidateHasValidation(ctx, data); err != nil {
-		return errors.Wrap(ctx, err, "validate failed")
-	}
-	return nil
+	return defaultParser.Parse(ctx, data)
 }
 
 // ParseAndPrint parses command-line arguments and environment variables into a struct,
@@ -96,19 +139,7 @@ func Parse(ctx context.Context, data interface{}) error {
 //
 // See Parse() documentation for supported types and struct tag options.
 func ParseAndPrint(ctx context.Context, data interface{}) error {
-	if err := ParseOnly(ctx, data); err != nil {
-		return errors.Wrap(ctx, err, "parse failed")
-	}
-	if err := Print(ctx, data); err != nil {
-		return errors.Wrap(ctx, err, "print failed")
-	}
-	if err := ValidateRequired(ctx, data); err != nil {
-		return errors.Wrap(ctx, err, "validate required failed")
-	}
-	if err := ValidateHasValidation(ctx, data); err != nil {
-		return errors.Wrap(ctx, err, "validate failed")
-	}
-	return nil
+	return defaultParser.ParseAndPrint(ctx, data)
 }
 
 // ParseOnly parses command-line arguments and environment variables into a struct
@@ -137,22 +168,7 @@ func ParseAndPrint(ctx context.Context, data interface{}) error {
 //
 // See Parse() documentation for supported types and struct tag options.
 func ParseOnly(ctx context.Context, data interface{}) error {
-	argsValues, err := argsToValuesExplicit(ctx, data, os.Args[1:])
-	if err != nil {
-		return errors.Wrap(ctx, err, "arg to values failed")
-	}
-	envValues, err := envToValues(ctx, data, os.Environ())
-	if err != nil {
-		return errors.Wrap(ctx, err, "env to values failed")
-	}
-	defaultValues, err := DefaultValues(ctx, data)
-	if err != nil {
-		return errors.Wrap(ctx, err, "default values failed")
-	}
-	if err := Fill(ctx, data, mergeValues(defaultValues, envValues, argsValues)); err != nil {
-		return errors.Wrap(ctx, err, "fill failed")
-	}
-	return nil
+	return defaultParser.ParseOnly(ctx, data)
 }
 
 func mergeValues(list ...map[string]interface{}) map[string]interface{} {
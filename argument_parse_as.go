@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/bborbe/errors"
+)
+
+// ParseAs parses command-line arguments and environment variables into a new T and validates it.
+// T must be a struct type. Without options it behaves like Parse, with options like
+// NewParser(opts...).Parse.
+// See Parse() documentation for supported types and struct tag options.
+//
+// Example:
+//
+//	config, err := argument.ParseAs[Config](ctx)
+//	if err != nil {
+//	    return err
+//	}
+func ParseAs[T any](ctx context.Context, opts ...Option) (T, error) {
+	var data T
+	if err := checkStruct[T](ctx); err != nil {
+		return data, err
+	}
+	if err := parserFor(opts).Parse(ctx, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// ParseOnlyAs works like ParseAs, but skips validation like ParseOnly.
+func ParseOnlyAs[T any](ctx context.Context, opts ...Option) (T, error) {
+	var data T
+	if err := checkStruct[T](ctx); err != nil {
+		return data, err
+	}
+	if err := parserFor(opts).ParseOnly(ctx, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// ParseEnvAs parses only the given environment variables into a new T like ParseEnv.
+func ParseEnvAs[T any](ctx context.Context, environ []string) (T, error) {
+	var data T
+	if err := checkStruct[T](ctx); err != nil {
+		return data, err
+	}
+	if err := defaultParser.ParseEnv(ctx, &data, environ); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// parserFor returns the default Parser without options, so ParseAs matches Parse.
+func parserFor(opts []Option) Parser {
+	if len(opts) == 0 {
+		return defaultParser
+	}
+	return NewParser(opts...)
+}
+
+// checkData returns an error if data is not a non-nil pointer to a struct.
+// All reflection in this package assumes that shape and would panic otherwise.
+func checkData(ctx context.Context, data interface{}) error {
+	if data == nil {
+		return errors.New(ctx, "data is nil, expected pointer to struct")
+	}
+	value := reflect.ValueOf(data)
+	if value.Kind() == reflect.Pointer && value.IsNil() {
+		return errors.Errorf(ctx, "data %T is nil, expected pointer to struct", data)
+	}
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return errors.Errorf(ctx, "unsupported type %T, expected pointer to struct", data)
+	}
+	return nil
+}
+
+// checkStruct returns an error if T is not a struct type.
+func checkStruct[T any](ctx context.Context) error {
+	if t := reflect.TypeOf((*T)(nil)).Elem(); t.Kind() != reflect.Struct {
+		return errors.Errorf(ctx, "unsupported type %v, expected struct", t)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+type testParseAsConfig struct {
+	Host string `arg:"host" env:"HOST" default:"localhost"`
+	Port int    `arg:"port" env:"PORT"                     required:"true"`
+}
+
+var _ = Describe("ParseAs", func() {
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	})
+	It("returns the parsed struct", func() {
+		cfg, err := argument.ParseAs[testParseAsConfig](
+			ctx,
+			testOptions([]string{"-port=8080"}, []string{"HOST=example.com"})...,
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Host).To(Equal("example.com"))
+		Expect(cfg.Port).To(Equal(8080))
+	})
+	It("uses os.Args and flag.CommandLine without options", func() {
+		os.Args = []string{"go", "-port=9090"}
+		cfg, err := argument.ParseAs[testParseAsConfig](ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Port).To(Equal(9090))
+		Expect(flag.CommandLine.Lookup("port")).NotTo(BeNil())
+	})
+	It("returns validation errors", func() {
+		_, err := argument.ParseAs[testParseAsConfig](
+			ctx,
+			testOptions([]string{}, []string{})...,
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Required field empty"))
+	})
+	It("returns error instead of panic for non-struct type", func() {
+		_, err := argument.ParseAs[int](ctx, argument.WithArgs([]string{}))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("unsupported type int, expected struct"))
+	})
+	It("returns error instead of panic for pointer type", func() {
+		_, err := argument.ParseAs[*testParseAsConfig](ctx, argument.WithArgs([]string{}))
+		Expect(err).To(HaveOccurred())
+		Expect(
+			err.Error(),
+		).To(Equal("unsupported type *argument_test.testParseAsConfig, expected struct"))
+	})
+	It("ParseOnlyAs skips validation", func() {
+		cfg, err := argument.ParseOnlyAs[testParseAsConfig](
+			ctx,
+			testOptions([]string{}, []string{})...,
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Host).To(Equal("localhost"))
+		Expect(cfg.Port).To(Equal(0))
+	})
+	It("ParseEnvAs parses the given environ", func() {
+		cfg, err := argument.ParseEnvAs[testParseAsConfig](ctx, []string{"PORT=1234"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Port).To(Equal(1234))
+		Expect(cfg.Host).To(BeEmpty())
+	})
+	It("ParseEnvAs returns error for non-struct type", func() {
+		_, err := argument.ParseEnvAs[[]string](ctx, []string{})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Parse with unsupported data", func() {
+	var ctx context.Context
+	var parser argument.Parser
+	BeforeEach(func() {
+		ctx = context.Background()
+		parser = newTestParser([]string{}, []string{})
+	})
+	It("returns error for nil", func() {
+		Expect(parser.Parse(ctx, nil)).NotTo(Succeed())
+	})
+	It("returns error for nil pointer", func() {
+		var cfg *testParseAsConfig
+		err := parser.Parse(ctx, cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is nil, expected pointer to struct"))
+	})
+	It("returns error for non-pointer struct", func() {
+		err := parser.Parse(ctx, testParseAsConfig{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("expected pointer to struct"))
+	})
+	It("returns error for pointer to non-struct", func() {
+		value := 42
+		Expect(parser.ParseOnly(ctx, &value)).NotTo(Succeed())
+		Expect(parser.ParseArgs(ctx, &value, []string{})).NotTo(Succeed())
+		Expect(parser.ParseEnv(ctx, &value, []string{})).NotTo(Succeed())
+		Expect(parser.ParseFile(ctx, &value, "config.yaml")).NotTo(Succeed())
+	})
+})
@@ -357,7 +357,7 @@ var _ = Describe("Parse", func() {
 
 			err := argument.ParseAndPrint(ctx, &args)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("validate required failed"))
+			Expect(err.Error()).To(ContainSubstring("validate failed"))
 		})
 
 		// Note: The Fill error path in parse function is difficult to trigger
@@ -609,3 +609,92 @@ var _ = Describe("Parse", func() {
 		})
 	})
 })
+
+type testKafkaConfig struct {
+	Brokers []string `arg:"brokers" env:"BROKERS" default:"localhost:9092"`
+	Topic   string   `arg:"topic"   env:"TOPIC"                            required:"true"`
+}
+
+type testDBConfig struct {
+	Host string `arg:"host" env:"HOST" default:"localhost"`
+	Port int    `arg:"port" env:"PORT" default:"5432"`
+}
+
+type TestCommonConfig struct {
+	Verbose bool `arg:"verbose" env:"VERBOSE"`
+}
+
+var _ = Describe("Parse nested structs", func() {
+	type config struct {
+		TestCommonConfig
+		Name     string          `arg:"name"      env:"NAME"`
+		Kafka    testKafkaConfig `arg:"kafka-"    env:"KAFKA_"`
+		Postgres *testDBConfig   `arg:"postgres-" env:"POSTGRES_"`
+		Plain    testDBConfig
+	}
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+	It("composes flag and env names from parent prefixes", func() {
+		parser := newTestParser(
+			[]string{"-kafka-topic=orders", "-postgres-port=6543", "-verbose"},
+			[]string{"KAFKA_BROKERS=a:9092,b:9092", "POSTGRES_HOST=db", "HOST=plain-db"},
+		)
+		var cfg config
+		Expect(parser.Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Verbose).To(BeTrue())
+		Expect(cfg.Kafka.Topic).To(Equal("orders"))
+		Expect(cfg.Kafka.Brokers).To(Equal([]string{"a:9092", "b:9092"}))
+		Expect(cfg.Postgres).NotTo(BeNil())
+		Expect(cfg.Postgres.Host).To(Equal("db"))
+		Expect(cfg.Postgres.Port).To(Equal(6543))
+		Expect(cfg.Plain.Host).To(Equal("plain-db"))
+		Expect(cfg.Plain.Port).To(Equal(5432))
+	})
+	It("applies defaults of nested fields", func() {
+		parser := newTestParser([]string{"-kafka-topic=orders"}, []string{})
+		var cfg config
+		Expect(parser.Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Kafka.Brokers).To(Equal([]string{"localhost:9092"}))
+		Expect(cfg.Postgres).NotTo(BeNil())
+		Expect(cfg.Postgres.Host).To(Equal("localhost"))
+	})
+	It("args override env of nested fields", func() {
+		parser := newTestParser(
+			[]string{"-kafka-topic=from-args"},
+			[]string{"KAFKA_TOPIC=from-env"},
+		)
+		var cfg config
+		Expect(parser.Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Kafka.Topic).To(Equal("from-args"))
+	})
+	It("reports prefixed names for missing required nested fields", func() {
+		parser := newTestParser([]string{}, []string{})
+		var cfg config
+		err := parser.Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(
+			err.Error(),
+		).To(ContainSubstring("define parameter kafka-topic or define env KAFKA_TOPIC"))
+	})
+	It("DefaultValues uses dotted field paths", func() {
+		var cfg config
+		values, err := argument.DefaultValues(ctx, &cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveKeyWithValue("Postgres.Host", "localhost"))
+		Expect(values).To(HaveKeyWithValue("Kafka.Brokers", []string{"localhost:9092"}))
+	})
+	It("Fill accepts dotted field paths", func() {
+		var cfg config
+		err := argument.Fill(ctx, &cfg, map[string]interface{}{
+			"Kafka.Topic":   "orders",
+			"Postgres.Port": 1234,
+			"Verbose":       true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Kafka.Topic).To(Equal("orders"))
+		Expect(cfg.Postgres.Port).To(Equal(1234))
+		Expect(cfg.Verbose).To(BeTrue())
+	})
+})
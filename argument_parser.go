@@ -0,0 +1,364 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o mocks/parser.go --fake-name Parser . Parser
+
+// Parser parses command-line arguments and environment variables into structs.
+//
+// A Parser created with NewParser owns its flag set, arguments and environment.
+// Every call registers the struct flags on a fresh flag.FlagSet, so the same Parser
+// can parse many structs (or the same struct many times) within one process
+// without the "flag redefined" panic caused by flag.CommandLine.
+//
+// The package-level functions Parse, ParseAndPrint, ParseOnly, ParseArgs, ParseEnv, ParseFile
+// and Dispatch are thin wrappers around a default Parser that uses flag.CommandLine, os.Args and os.Environ.
+type Parser interface {
+	// Parse parses arguments and environment variables into data and validates it.
+	// See Parse() documentation for supported types and struct tag options.
+	Parse(ctx context.Context, data interface{}) error
+	// ParseAndPrint works like Parse, but prints the parsed configuration before validation.
+	ParseAndPrint(ctx context.Context, data interface{}) error
+	// ParseWithReport works like Parse and additionally returns the source of every field value.
+	// See ParseWithReport() documentation for details.
+	ParseWithReport(ctx context.Context, data interface{}) (Provenance, error)
+	// ParseOnly parses arguments and environment variables into data without validation.
+	ParseOnly(ctx context.Context, data interface{}) error
+	// ParseArgs parses only the given command-line arguments into data.
+	ParseArgs(ctx context.Context, data interface{}, args []string) error
+	// ParseEnv parses only the given environment variables into data.
+	ParseEnv(ctx context.Context, data interface{}, environ []string) error
+	// ParseFile parses only the given JSON or YAML config file into data.
+	ParseFile(ctx context.Context, data interface{}, path string) error
+	// Dispatch parses global flags into global and runs the subcommand named by the next argument.
+	// See Dispatch() documentation for details.
+	Dispatch(ctx context.Context, global interface{}, commands ...Command) error
+}
+
+// Option configures a Parser created by NewParser.
+type Option func(p *parser)
+
+// WithArgs sets the command-line arguments used by Parse, ParseAndPrint, ParseOnly and Dispatch.
+// Defaults to os.Args[1:].
+func WithArgs(args []string) Option {
+	return func(p *parser) {
+		p.args = func() []string { return args }
+	}
+}
+
+// WithEnviron sets the environment variables used by Parse, ParseAndPrint, ParseOnly and Dispatch.
+// Entries use the "KEY=value" format of os.Environ. Defaults to os.Environ().
+func WithEnviron(environ []string) Option {
+	return func(p *parser) {
+		p.environ = func() []string { return environ }
+	}
+}
+
+// WithName sets the name of the flag set used in usage and error messages.
+// Defaults to os.Args[0].
+func WithName(name string) Option {
+	return func(p *parser) {
+		p.name = name
+	}
+}
+
+// WithOutput sets the writer that receives flag usage and error messages.
+// Defaults to os.Stderr.
+func WithOutput(output io.Writer) Option {
+	return func(p *parser) {
+		p.output = output
+	}
+}
+
+// WithPrinter sets the Printer used by ParseAndPrint.
+// Defaults to NewLogPrinter().
+func WithPrinter(printer Printer) Option {
+	return func(p *parser) {
+		p.printer = printer
+	}
+}
+
+// WithLogger sets the logger that receives warnings about deprecated and ignored
+// flag and env aliases. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(p *parser) {
+		p.logger = logger
+	}
+}
+
+// NewParser returns a Parser that does not touch flag.CommandLine.
+//
+// Example:
+//
+//	parser := argument.NewParser(
+//	    argument.WithArgs([]string{"-port=8080"}),
+//	    argument.WithEnviron([]string{"HOST=localhost"}),
+//	)
+//	if err := parser.Parse(ctx, &config); err != nil {
+//	    return err
+//	}
+func NewParser(opts ...Option) Parser {
+	p := &parser{
+		name:    os.Args[0],
+		args:    func() []string { return os.Args[1:] },
+		environ: os.Environ,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.flagSet = func() *flag.FlagSet {
+		flagSet := flag.NewFlagSet(p.name, flag.ContinueOnError)
+		if p.output != nil {
+			flagSet.SetOutput(p.output)
+		}
+		return flagSet
+	}
+	return p
+}
+
+// defaultParser backs the package-level functions. It resolves flag.CommandLine, os.Args
+// and os.Environ on every call, so replacing flag.CommandLine (e.g. in tests) keeps working.
+var defaultParser = &parser{
+	flagSet: func() *flag.FlagSet { return flag.CommandLine },
+	args:    func() []string { return os.Args[1:] },
+	environ: os.Environ,
+}
+
+type parser struct {
+	name    string
+	output  io.Writer
+	printer Printer
+	logger  *slog.Logger
+	// gnu is set by WithGNUFlags.
+	gnu bool
+	// strictEnvPrefix and strictEnvWarning are set by WithStrictEnv and WithStrictEnvWarning.
+	strictEnvPrefix  string
+	strictEnvWarning bool
+	// envPrefix is set by WithEnvPrefix.
+	envPrefix string
+	// naming is set by WithNaming.
+	naming  Naming
+	flagSet func() *flag.FlagSet
+	args    func() []string
+	environ func() []string
+	// secretResolvers holds the resolvers registered with WithSecretResolver.
+	secretResolvers secretResolvers
+}
+
+// log returns the logger set with WithLogger or slog.Default().
+func (p *parser) log() *slog.Logger {
+	if p.logger == nil {
+		return slog.Default()
+	}
+	return p.logger
+}
+
+// fieldOptions returns the options that change the names of fields.
+func (p *parser) fieldOptions() fieldOptions {
+	return fieldOptions{envPrefix: p.envPrefix, naming: p.naming}
+}
+
+func (p *parser) Parse(ctx context.Context, data interface{}) error {
+	if err := p.ParseOnly(ctx, data); err != nil {
+		return errors.Wrap(ctx, err, "parse failed")
+	}
+	if err := validate(ctx, data, p.fieldOptions()); err != nil {
+		return errors.Wrap(ctx, err, "validate failed")
+	}
+	return nil
+}
+
+func (p *parser) ParseWithReport(ctx context.Context, data interface{}) (Provenance, error) {
+	flagSet := p.flagSet()
+	setUsage(flagSet, data, p.fieldOptions())
+	provenance, err := p.parseOnly(ctx, flagSet, data, p.args())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "parse failed")
+	}
+	if err := p.checkUnknownEnv(ctx, p.environ(), data); err != nil {
+		return nil, errors.Wrap(ctx, err, "parse failed")
+	}
+	if err := validate(ctx, data, p.fieldOptions()); err != nil {
+		return provenance, errors.Wrap(ctx, err, "validate failed")
+	}
+	return provenance, nil
+}
+
+// ParseAndPrint prints the source of every field value next to it.
+func (p *parser) ParseAndPrint(ctx context.Context, data interface{}) error {
+	flagSet := p.flagSet()
+	setUsage(flagSet, data, p.fieldOptions())
+	provenance, err := p.parseOnly(ctx, flagSet, data, p.args())
+	if err != nil {
+		return errors.Wrap(ctx, err, "parse failed")
+	}
+	if err := p.checkUnknownEnv(ctx, p.environ(), data); err != nil {
+		return errors.Wrap(ctx, err, "parse failed")
+	}
+	printer := p.printer
+	if printer == nil {
+		printer = NewLogPrinter()
+	}
+	if err := PrintWithProvenance(ctx, data, provenance, printer); err != nil {
+		return errors.Wrap(ctx, err, "print failed")
+	}
+	if err := validate(ctx, data, p.fieldOptions()); err != nil {
+		return errors.Wrap(ctx, err, "validate failed")
+	}
+	return nil
+}
+
+func (p *parser) ParseOnly(ctx context.Context, data interface{}) error {
+	flagSet := p.flagSet()
+	setUsage(flagSet, data, p.fieldOptions())
+	if _, err := p.parseOnly(ctx, flagSet, data, p.args()); err != nil {
+		return err
+	}
+	return p.checkUnknownEnv(ctx, p.environ(), data)
+}
+
+// parseOnly fills data from args, env, config file and defaults using the given flag set
+// and returns the source of every field value.
+// After it returns, flagSet.Args() holds the arguments left after the flags.
+func (p *parser) parseOnly(
+	ctx context.Context,
+	flagSet *flag.FlagSet,
+	data interface{},
+	args []string,
+) (Provenance, error) {
+	if err := checkData(ctx, data); err != nil {
+		return nil, err
+	}
+	environ := p.environ()
+	secrets := p.secretResolversFor(environ)
+	argsSources := make(Provenance)
+	argsValues, err := argsToValuesExplicit(
+		ctx,
+		flagSet,
+		data,
+		args,
+		secrets,
+		argsSources,
+		p.log(),
+		p.gnu,
+		p.fieldOptions(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "arg to values failed")
+	}
+	envSources := make(Provenance)
+	envValues, err := envToValues(
+		ctx,
+		data,
+		environ,
+		secrets,
+		envSources,
+		p.log(),
+		p.fieldOptions(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "env to values failed")
+	}
+	fileSources := make(Provenance)
+	fileValues := make(map[string]interface{})
+	if path := configFilePath(flagSet, data, environ); path != "" {
+		fileValues, err = fileToValues(ctx, data, path, secrets, fileSources)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, "file to values failed")
+		}
+	}
+	values := mergeValues(fileValues, envValues, argsValues)
+	defaultSources := make(Provenance)
+	defaultValues, err := defaultValues(ctx, data, secrets, defaultSources, values)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "default values failed")
+	}
+	if err := Fill(ctx, data, mergeValues(defaultValues, values)); err != nil {
+		return nil, errors.Wrap(ctx, err, "fill failed")
+	}
+	return mergeProvenance(defaultSources, fileSources, envSources, argsSources), nil
+}
+
+func (p *parser) ParseArgs(ctx context.Context, data interface{}, args []string) error {
+	if err := checkData(ctx, data); err != nil {
+		return err
+	}
+	flagSet := p.flagSet()
+	setUsage(flagSet, data, p.fieldOptions())
+	values, err := argsToValues(
+		ctx,
+		flagSet,
+		data,
+		args,
+		p.secretResolversFor(p.environ()),
+		p.log(),
+		p.gnu,
+		p.fieldOptions(),
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, "args to values failed")
+	}
+	if err := positionalToValues(
+		ctx,
+		data,
+		flagSet.Args(),
+		values,
+		p.secretResolversFor(p.environ()),
+		nil,
+		p.fieldOptions(),
+	); err != nil {
+		return errors.Wrap(ctx, err, "parse positional arguments failed")
+	}
+	if err := Fill(ctx, data, values); err != nil {
+		return errors.Wrap(ctx, err, "fill failed")
+	}
+	return nil
+}
+
+func (p *parser) ParseEnv(ctx context.Context, data interface{}, environ []string) error {
+	if err := checkData(ctx, data); err != nil {
+		return err
+	}
+	values, err := envToValues(
+		ctx,
+		data,
+		environ,
+		p.secretResolversFor(environ),
+		nil,
+		p.log(),
+		p.fieldOptions(),
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, "env to values failed")
+	}
+	if err := Fill(ctx, data, values); err != nil {
+		return errors.Wrap(ctx, err, "fill failed")
+	}
+	return p.checkUnknownEnv(ctx, environ, data)
+}
+
+func (p *parser) ParseFile(ctx context.Context, data interface{}, path string) error {
+	if err := checkData(ctx, data); err != nil {
+		return err
+	}
+	values, err := fileToValues(ctx, data, path, p.secretResolversFor(p.environ()), nil)
+	if err != nil {
+		return errors.Wrap(ctx, err, "file to values failed")
+	}
+	if err := Fill(ctx, data, values); err != nil {
+		return errors.Wrap(ctx, err, "fill failed")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("Parser", func() {
+	var ctx context.Context
+	var output *bytes.Buffer
+	BeforeEach(func() {
+		ctx = context.Background()
+		output = &bytes.Buffer{}
+		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	})
+	type config struct {
+		Host string `arg:"host" env:"HOST" default:"localhost"`
+		Port int    `arg:"port" env:"PORT" default:"8080"      required:"true"`
+	}
+	It("parses args, env and defaults with precedence", func() {
+		parser := newTestParser([]string{"-port=9090"}, []string{"HOST=example.com", "PORT=7070"})
+		var cfg config
+		Expect(parser.Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Host).To(Equal("example.com"))
+		Expect(cfg.Port).To(Equal(9090))
+	})
+	It("can be called multiple times without flag redefined panic", func() {
+		parser := newTestParser([]string{"-port=9090"}, []string{})
+		var first config
+		Expect(parser.Parse(ctx, &first)).To(Succeed())
+		var second config
+		Expect(parser.Parse(ctx, &second)).To(Succeed())
+		Expect(second.Port).To(Equal(9090))
+	})
+	It("does not register flags on flag.CommandLine", func() {
+		parser := newTestParser([]string{}, []string{})
+		var cfg config
+		Expect(parser.ParseOnly(ctx, &cfg)).To(Succeed())
+		Expect(flag.CommandLine.Lookup("host")).To(BeNil())
+		Expect(flag.CommandLine.Lookup("port")).To(BeNil())
+	})
+	It("returns required error", func() {
+		parser := newTestParser([]string{"-port=0"}, []string{})
+		var cfg config
+		err := parser.Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Required field empty"))
+	})
+	It("ParseArgs uses the given args", func() {
+		parser := argument.NewParser(argument.WithOutput(output))
+		var cfg config
+		Expect(parser.ParseArgs(ctx, &cfg, []string{"-host=args.example.com"})).To(Succeed())
+		Expect(cfg.Host).To(Equal("args.example.com"))
+		Expect(cfg.Port).To(Equal(8080))
+	})
+	It("ParseEnv uses the given environ", func() {
+		parser := argument.NewParser(argument.WithOutput(output))
+		var cfg config
+		Expect(parser.ParseEnv(ctx, &cfg, []string{"PORT=1234"})).To(Succeed())
+		Expect(cfg.Port).To(Equal(1234))
+		Expect(cfg.Host).To(BeEmpty())
+	})
+	It("writes flag errors to the configured output", func() {
+		parser := newTestParser(
+			[]string{"-unknown=1"},
+			[]string{},
+			argument.WithName("myapp"),
+			argument.WithOutput(output),
+		)
+		var cfg config
+		Expect(parser.ParseOnly(ctx, &cfg)).NotTo(Succeed())
+		Expect(output.String()).To(ContainSubstring("flag provided but not defined: -unknown"))
+	})
+})
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+// posRest is the pos tag of the slice field that receives all remaining positional arguments.
+const posRest = "rest"
+
+// posName returns the name of a positional field shown in usage and messages,
+// the upper case Go field name (e.g. "SRC" or "FILES..." for pos:"rest").
+func (f field) posName() string {
+	name := strings.ToUpper(f.structField.Name)
+	if f.pos == posRest {
+		return name + "..."
+	}
+	return name
+}
+
+// positionalFields returns the fields with pos:"0", pos:"1", ... ordered by index and the
+// field with pos:"rest" or nil.
+func positionalFields(
+	ctx context.Context,
+	data interface{},
+	options fieldOptions,
+) ([]field, *field, error) {
+	var fields []field
+	var rest *field
+	indexes := make(map[string]int)
+	if err := walkFieldsWith(data, options, func(f field) error {
+		if !f.hasPos {
+			return nil
+		}
+		if f.hasArg {
+			return errors.Errorf(ctx, "field %s must not have both arg and pos tag", f.path)
+		}
+		if !isSupported(f) {
+			return errors.Errorf(
+				ctx,
+				"field %s with type %T is unsupported",
+				f.path,
+				f.value.Interface(),
+			)
+		}
+		if f.pos == posRest {
+			if rest != nil {
+				return errors.Errorf(
+					ctx,
+					"pos tag rest of field %s is already used by field %s",
+					f.path,
+					rest.path,
+				)
+			}
+			if !isListField(f) || valueType(f).Kind() != reflect.Slice {
+				return errors.Errorf(ctx, "pos tag rest of field %s requires a slice", f.path)
+			}
+			rest = &f
+			return nil
+		}
+		index, err := strconv.Atoi(f.pos)
+		if err != nil || index < 0 {
+			return errors.Errorf(
+				ctx,
+				"pos tag %q of field %s must be an index or rest",
+				f.pos,
+				f.path,
+			)
+		}
+		indexes[f.path] = index
+		fields = append(fields, f)
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return indexes[fields[i].path] < indexes[fields[j].path]
+	})
+	for i, f := range fields {
+		if indexes[f.path] != i {
+			return nil, nil, errors.Errorf(
+				ctx,
+				"pos tag %q of field %s must be %d, positions start at 0 without gaps",
+				f.pos,
+				f.path,
+				i,
+			)
+		}
+	}
+	return fields, rest, nil
+}
+
+// positionalToValues converts the arguments left after the flags into the values of the
+// positional fields of data. Missing arguments leave the fields unset, so required:"true"
+// reports them. Arguments without positional field return an error, unless data has no
+// positional fields at all.
+func positionalToValues(
+	ctx context.Context,
+	data interface{},
+	args []string,
+	values map[string]interface{},
+	secrets secretResolvers,
+	sources Provenance,
+	options fieldOptions,
+) error {
+	fields, rest, err := positionalFields(ctx, data, options)
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 && rest == nil {
+		return nil
+	}
+	for i, f := range fields {
+		if i == len(args) {
+			return nil
+		}
+		value, err := secrets.resolve(ctx, f, args[i])
+		if err != nil {
+			return err
+		}
+		result, err := convertField(ctx, f, value)
+		var overflowError *OverflowError
+		if errors.As(err, &overflowError) {
+			return err
+		}
+		if err != nil {
+			return errors.Wrapf(ctx, err, "parse argument %s failed", f.posName())
+		}
+		values[f.path] = result
+		sources.add(f, Source{Kind: SourceKindPositional, Name: f.posName(), Raw: args[i]})
+	}
+	remaining := args[len(fields):]
+	if len(remaining) == 0 {
+		return nil
+	}
+	if rest == nil {
+		return errors.Errorf(ctx, "unexpected argument %q", remaining[0])
+	}
+	elemType := valueType(*rest).Elem()
+	result := reflect.MakeSlice(reflect.SliceOf(convertedType(elemType)), 0, len(remaining))
+	for _, arg := range remaining {
+		value, err := secrets.resolve(ctx, *rest, arg)
+		if err != nil {
+			return err
+		}
+		elem, err := convertElem(ctx, value, elemType)
+		if err != nil {
+			return errors.Wrapf(ctx, err, "parse argument %s failed", rest.posName())
+		}
+		result = reflect.Append(result, elem)
+	}
+	values[rest.path] = result.Interface()
+	sources.add(*rest, Source{
+		Kind: SourceKindPositional,
+		Name: rest.posName(),
+		Raw:  strings.Join(remaining, " "),
+	})
+	return nil
+}
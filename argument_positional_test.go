@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("Positional arguments", func() {
+	type config struct {
+		Verbose bool     `arg:"verbose"`
+		Src     string   `              pos:"0"    required:"true" usage:"source file"`
+		Dst     string   `              pos:"1"                                        env:"DST" pattern:"^/"`
+		Files   []string `              pos:"rest"`
+	}
+	var ctx context.Context
+	var cfg config
+	BeforeEach(func() {
+		ctx = context.Background()
+		cfg = config{}
+	})
+	It("binds the arguments after the flags", func() {
+		provenance, err := newTestParser(
+			[]string{"-verbose", "a.txt", "/tmp", "b.txt", "c.txt"},
+			nil,
+		).ParseWithReport(ctx, &cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg).To(Equal(config{
+			Verbose: true,
+			Src:     "a.txt",
+			Dst:     "/tmp",
+			Files:   []string{"b.txt", "c.txt"},
+		}))
+		Expect(provenance["Src"]).To(Equal(argument.Source{
+			Kind: argument.SourceKindPositional,
+			Name: "SRC",
+			Raw:  "a.txt",
+		}))
+		Expect(provenance["Files"].String()).To(Equal("positional FILES..."))
+		Expect(provenance["Files"].Raw).To(Equal("b.txt c.txt"))
+	})
+	It("keeps the priority of args over env", func() {
+		Expect(
+			newTestParser([]string{"a.txt"}, []string{"DST=/env"}).Parse(ctx, &cfg),
+		).To(Succeed())
+		Expect(cfg.Dst).To(Equal("/env"))
+		Expect(
+			newTestParser([]string{"a.txt", "/arg"}, []string{"DST=/env"}).Parse(ctx, &cfg),
+		).To(Succeed())
+		Expect(cfg.Dst).To(Equal("/arg"))
+	})
+	It("reports missing required arguments", func() {
+		err := newTestParser([]string{"-verbose"}, nil).Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Required field empty, define argument SRC"))
+		Expect(errors.Is(err, argument.ErrRequired)).To(BeTrue())
+	})
+	It("validates arguments with validation tags", func() {
+		err := newTestParser([]string{"a.txt", "tmp"}, nil).Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			`Invalid field, argument DST or env DST: value must match ^/, got "tmp"`,
+		))
+	})
+	It("converts arguments to the field type", func() {
+		var typed struct {
+			Count int   `pos:"0"`
+			Ports []int `pos:"rest"`
+		}
+		Expect(newTestParser([]string{"3", "80", "443"}, nil).ParseOnly(ctx, &typed)).To(Succeed())
+		Expect(typed.Count).To(Equal(3))
+		Expect(typed.Ports).To(Equal([]int{80, 443}))
+		err := newTestParser([]string{"three"}, nil).ParseOnly(ctx, &typed)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("parse argument COUNT failed"))
+	})
+	It("returns error for unexpected arguments", func() {
+		var single struct {
+			Name string `pos:"0"`
+		}
+		err := newTestParser([]string{"a", "b"}, nil).ParseOnly(ctx, &single)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unexpected argument "b"`))
+	})
+	It("ignores arguments of structs without positional fields", func() {
+		var flags struct {
+			Verbose bool `arg:"verbose"`
+		}
+		Expect(
+			newTestParser([]string{"-verbose", "a", "b"}, nil).ParseOnly(ctx, &flags),
+		).To(Succeed())
+	})
+	It("works with ParseArgs", func() {
+		parser := argument.NewParser(argument.WithOutput(&bytes.Buffer{}))
+		Expect(parser.ParseArgs(ctx, &cfg, []string{"a.txt", "/tmp"})).To(Succeed())
+		Expect(cfg.Src).To(Equal("a.txt"))
+		Expect(cfg.Dst).To(Equal("/tmp"))
+	})
+	DescribeTable("returns error for invalid pos tags",
+		func(data interface{}, expected string) {
+			err := newTestParser(nil, nil).ParseOnly(ctx, data)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(expected))
+		},
+		Entry("gap", &struct {
+			A string `pos:"0"`
+			B string `pos:"2"`
+		}{}, `pos tag "2" of field B must be 1`),
+		Entry("not a number", &struct {
+			A string `pos:"first"`
+		}{}, `pos tag "first" of field A must be an index or rest`),
+		Entry("rest without slice", &struct {
+			A string `pos:"rest"`
+		}{}, "pos tag rest of field A requires a slice"),
+		Entry("arg and pos", &struct {
+			A string `arg:"a" pos:"0"`
+		}{}, "field A must not have both arg and pos tag"),
+	)
+	It("lists positional arguments in usage", func() {
+		buf := &bytes.Buffer{}
+		Expect(argument.Usage(buf, &cfg)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("  SRC"))
+		Expect(buf.String()).To(ContainSubstring("source file"))
+		Expect(buf.String()).To(ContainSubstring("  FILES..."))
+	})
+})
@@ -164,3 +164,37 @@ Argument: NonSliceField 'regular'
 func float64Ptr(value float64) *float64 {
 	return &value
 }
+
+var _ = Describe("Print nested structs", func() {
+	type database struct {
+		Host     string
+		Password string `display:"length"`
+	}
+	var buf *bytes.Buffer
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+		buf = &bytes.Buffer{}
+		log.SetOutput(buf)
+		log.SetFlags(0)
+	})
+	It("prints nested fields with their field path", func() {
+		args := struct {
+			Name     string
+			Database database
+			Replica  *database
+		}{
+			Name:     "app",
+			Database: database{Host: "db", Password: "secret"},
+			Replica:  &database{Host: "replica"},
+		}
+		err := argument.Print(ctx, &args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(Equal(`Argument: Name 'app'
+Argument: Database.Host 'db'
+Argument: Database.Password length 6
+Argument: Replica.Host 'replica'
+Argument: Replica.Password length 0
+`))
+	})
+})
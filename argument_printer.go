@@ -0,0 +1,317 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o mocks/printer.go --fake-name Printer . Printer
+
+// Printer outputs the parsed configuration.
+// Fields with display:"hidden" are never passed to a Printer.
+type Printer interface {
+	// Print outputs all fields of one struct.
+	Print(ctx context.Context, fields []PrintField) error
+}
+
+// PrintField is a single field passed to a Printer.
+type PrintField struct {
+	// Name is the dotted Go field path (e.g. "Kafka.Brokers").
+	Name string
+	// Value is the field value. Pointers are dereferenced, nil pointers are nil.
+	// Values of types with a Converter Format func are formatted strings.
+	// Value is nil for fields with display:"length".
+	Value interface{}
+	// Masked is true for fields with display:"length", only Length may be printed.
+	Masked bool
+	// Length is the length of the formatted value of a masked field.
+	Length int
+	// Source is where the value came from. Its Kind is empty if unknown,
+	// e.g. for PrintWith or fields not set by parsing.
+	Source Source
+}
+
+// PrintWith passes all fields of data to the given printer.
+// Set display:"hidden" to hide or display:"length" to only print the length of a field.
+//
+// Example:
+//
+//	argument.PrintWith(ctx, &config, argument.NewSlogPrinter(slog.Default()))
+func PrintWith(ctx context.Context, data interface{}, printer Printer) error {
+	return PrintWithProvenance(ctx, data, nil, printer)
+}
+
+// PrintWithProvenance works like PrintWith and passes the source of every field from
+// provenance to the printer. NewLogPrinter and NewTextPrinter show it after the value:
+//
+//	Argument: Timeout '30s' (env TIMEOUT)
+//
+// Example:
+//
+//	provenance, err := argument.ParseWithReport(ctx, &config)
+//	...
+//	argument.PrintWithProvenance(ctx, &config, provenance, argument.NewLogPrinter())
+func PrintWithProvenance(
+	ctx context.Context,
+	data interface{},
+	provenance Provenance,
+	printer Printer,
+) error {
+	fields, err := printFields(data, provenance)
+	if err != nil {
+		return errors.Wrap(ctx, err, "collect fields failed")
+	}
+	if err := printer.Print(ctx, fields); err != nil {
+		return errors.Wrap(ctx, err, "print failed")
+	}
+	return nil
+}
+
+// printFields collects all printable fields of data with their source.
+func printFields(data interface{}, provenance Provenance) ([]PrintField, error) {
+	var fields []PrintField
+	if err := walkFields(data, func(f field) error {
+		display := f.display()
+		if display == "hidden" {
+			return nil
+		}
+		value := formatValue(printValue(f.value))
+		if display == "length" {
+			length := 0
+			if value != nil {
+				length = len(fmt.Sprintf("%v", value))
+			}
+			fields = append(fields, PrintField{
+				Name:   f.path,
+				Masked: true,
+				Length: length,
+				Source: provenance[f.path],
+			})
+			return nil
+		}
+		fields = append(fields, PrintField{Name: f.path, Value: value, Source: provenance[f.path]})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// printValue dereferences pointers and interfaces and returns nil for nil ones.
+func printValue(value reflect.Value) interface{} {
+	if value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		return value.Elem().Interface()
+	}
+	return value.Interface()
+}
+
+// NewLogPrinter returns the Printer used by Print and ParseAndPrint.
+// It writes one line per field through the standard log package:
+//
+//	Argument: Username 'Ben'
+//	Argument: Password length 6
+//	Argument: Brokers [2]: kafka1:9092, kafka2:9092
+//
+// The source of a field is appended if known (e.g. "Argument: Timeout '30s' (env TIMEOUT)").
+func NewLogPrinter() Printer {
+	return &logPrinter{}
+}
+
+type logPrinter struct{}
+
+func (l *logPrinter) Print(ctx context.Context, fields []PrintField) error {
+	for _, f := range fields {
+		switch {
+		case f.Masked:
+			log.Printf("Argument: %s length %d%s", f.Name, f.Length, sourceSuffix(f))
+		case f.Value == nil:
+			log.Printf("Argument: %s <nil>%s", f.Name, sourceSuffix(f))
+		case reflect.ValueOf(f.Value).Kind() == reflect.Slice:
+			// Format slices as comma-separated values with count
+			values := sliceValues(f.Value)
+			if len(values) == 0 {
+				log.Printf("Argument: %s []%s", f.Name, sourceSuffix(f))
+			} else {
+				log.Printf(
+					"Argument: %s [%d]: %s%s",
+					f.Name,
+					len(values),
+					strings.Join(values, ", "),
+					sourceSuffix(f),
+				)
+			}
+		case reflect.ValueOf(f.Value).Kind() == reflect.Map:
+			// Format maps as sorted key=value pairs with count
+			entries := mapEntries(f.Value)
+			if len(entries) == 0 {
+				log.Printf("Argument: %s {}%s", f.Name, sourceSuffix(f))
+			} else {
+				log.Printf(
+					"Argument: %s {%d}: %s%s",
+					f.Name,
+					len(entries),
+					strings.Join(entries, ", "),
+					sourceSuffix(f),
+				)
+			}
+		default:
+			log.Printf("Argument: %s '%v'%s", f.Name, f.Value, sourceSuffix(f))
+		}
+	}
+	return nil
+}
+
+// sourceSuffix returns " (env TIMEOUT)" for fields with known source and "" otherwise.
+func sourceSuffix(f PrintField) string {
+	if f.Source.Kind == "" {
+		return ""
+	}
+	return " (" + f.Source.String() + ")"
+}
+
+// NewSlogPrinter returns a Printer that logs one record with one attribute per field.
+// Masked fields are logged as a group with their length (e.g. Password.length=6).
+func NewSlogPrinter(logger *slog.Logger) Printer {
+	return &slogPrinter{
+		logger: logger,
+	}
+}
+
+type slogPrinter struct {
+	logger *slog.Logger
+}
+
+func (s *slogPrinter) Print(ctx context.Context, fields []PrintField) error {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, f := range fields {
+		if f.Masked {
+			attrs = append(attrs, slog.Group(f.Name, slog.Int("length", f.Length)))
+			continue
+		}
+		attrs = append(attrs, slog.Any(f.Name, f.Value))
+	}
+	s.logger.LogAttrs(ctx, slog.LevelInfo, "arguments", attrs...)
+	return nil
+}
+
+// NewJSONPrinter returns a Printer that writes all fields as a single JSON object.
+// Nested struct fields become nested objects, masked fields become {"length": n}.
+// Values implementing fmt.Stringer but not json.Marshaler (e.g. time.Duration)
+// are written as their string.
+func NewJSONPrinter(w io.Writer) Printer {
+	return &jsonPrinter{
+		w: w,
+	}
+}
+
+type jsonPrinter struct {
+	w io.Writer
+}
+
+func (j *jsonPrinter) Print(ctx context.Context, fields []PrintField) error {
+	values := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		if f.Masked {
+			values[f.Name] = map[string]int{"length": f.Length}
+			continue
+		}
+		values[f.Name] = jsonPrintValue(f.Value)
+	}
+	if err := json.NewEncoder(j.w).Encode(nestValues(values)); err != nil {
+		return errors.Wrap(ctx, err, "encode json failed")
+	}
+	return nil
+}
+
+func jsonPrintValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case json.Marshaler:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice:
+		result := make([]interface{}, rv.Len())
+		for i := range result {
+			result[i] = jsonPrintValue(rv.Index(i).Interface())
+		}
+		return result
+	case reflect.Map:
+		result := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			result[fmt.Sprintf("%v", iter.Key().Interface())] = jsonPrintValue(
+				iter.Value().Interface(),
+			)
+		}
+		return result
+	}
+	return value
+}
+
+// NewTextPrinter returns a Printer that writes a plain NAME/VALUE table to w.
+func NewTextPrinter(w io.Writer) Printer {
+	return &textPrinter{
+		w: w,
+	}
+}
+
+type textPrinter struct {
+	w io.Writer
+}
+
+func (t *textPrinter) Print(ctx context.Context, fields []PrintField) error {
+	tw := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tVALUE")
+	for _, f := range fields {
+		fmt.Fprintf(tw, "%s\t%s%s\n", f.Name, textPrintValue(f), sourceSuffix(f))
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(ctx, err, "flush text failed")
+	}
+	return nil
+}
+
+func textPrintValue(f PrintField) string {
+	switch {
+	case f.Masked:
+		return fmt.Sprintf("length %d", f.Length)
+	case f.Value == nil:
+		return "<nil>"
+	case reflect.ValueOf(f.Value).Kind() == reflect.Slice:
+		return "[" + strings.Join(sliceValues(f.Value), ", ") + "]"
+	case reflect.ValueOf(f.Value).Kind() == reflect.Map:
+		return "{" + strings.Join(mapEntries(f.Value), ", ") + "}"
+	default:
+		return fmt.Sprintf("%v", f.Value)
+	}
+}
+
+func sliceValues(value interface{}) []string {
+	rv := reflect.ValueOf(value)
+	result := make([]string, rv.Len())
+	for i := range result {
+		result[i] = fmt.Sprintf("%v", rv.Index(i).Interface())
+	}
+	return result
+}
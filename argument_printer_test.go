@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+	"github.com/bborbe/argument/v2/mocks"
+)
+
+var _ = Describe("Printer", func() {
+	type kafka struct {
+		Brokers []string
+		Topic   *string
+	}
+	type config struct {
+		Name     string
+		Password string `display:"length"`
+		Token    string `display:"hidden"`
+		Timeout  time.Duration
+		Kafka    kafka
+	}
+	var ctx context.Context
+	var buf *bytes.Buffer
+	var cfg config
+	BeforeEach(func() {
+		ctx = context.Background()
+		buf = &bytes.Buffer{}
+		cfg = config{
+			Name:     "app",
+			Password: "S3CR3T",
+			Token:    "token",
+			Timeout:  time.Minute,
+			Kafka:    kafka{Brokers: []string{"kafka1:9092", "kafka2:9092"}},
+		}
+	})
+	It("passes fields to the printer", func() {
+		printer := &mocks.Printer{}
+		Expect(argument.PrintWith(ctx, &cfg, printer)).To(Succeed())
+		Expect(printer.PrintCallCount()).To(Equal(1))
+		_, fields := printer.PrintArgsForCall(0)
+		Expect(fields).To(Equal([]argument.PrintField{
+			{Name: "Name", Value: "app"},
+			{Name: "Password", Masked: true, Length: 6},
+			{Name: "Timeout", Value: time.Minute},
+			{Name: "Kafka.Brokers", Value: []string{"kafka1:9092", "kafka2:9092"}},
+			{Name: "Kafka.Topic"},
+		}))
+	})
+	It("returns the error of the printer", func() {
+		printer := &mocks.Printer{}
+		printer.PrintReturns(errors.New(ctx, "banana"))
+		Expect(argument.PrintWith(ctx, &cfg, printer)).NotTo(Succeed())
+	})
+	It("slog printer logs one attribute per field", func() {
+		logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+		Expect(argument.PrintWith(ctx, &cfg, argument.NewSlogPrinter(logger))).To(Succeed())
+		Expect(buf.String()).To(Equal(
+			"level=INFO msg=arguments Name=app Password.length=6 Timeout=1m0s " +
+				"Kafka.Brokers=\"[kafka1:9092 kafka2:9092]\" Kafka.Topic=<nil>\n",
+		))
+		Expect(buf.String()).NotTo(ContainSubstring("S3CR3T"))
+		Expect(buf.String()).NotTo(ContainSubstring("token"))
+	})
+	It("json printer writes a single object", func() {
+		Expect(argument.PrintWith(ctx, &cfg, argument.NewJSONPrinter(buf))).To(Succeed())
+		var result map[string]interface{}
+		Expect(json.Unmarshal(buf.Bytes(), &result)).To(Succeed())
+		Expect(result).To(Equal(map[string]interface{}{
+			"Name":     "app",
+			"Password": map[string]interface{}{"length": float64(6)},
+			"Timeout":  "1m0s",
+			"Kafka": map[string]interface{}{
+				"Brokers": []interface{}{"kafka1:9092", "kafka2:9092"},
+				"Topic":   nil,
+			},
+		}))
+	})
+	It("text printer writes a table", func() {
+		Expect(argument.PrintWith(ctx, &cfg, argument.NewTextPrinter(buf))).To(Succeed())
+		Expect(buf.String()).To(Equal(`NAME           VALUE
+Name           app
+Password       length 6
+Timeout        1m0s
+Kafka.Brokers  [kafka1:9092, kafka2:9092]
+Kafka.Topic    <nil>
+`))
+	})
+	It("ParseAndPrint uses the printer of the parser", func() {
+		parser := newTestParser(
+			[]string{},
+			[]string{},
+			argument.WithPrinter(argument.NewJSONPrinter(buf)),
+		)
+		var data struct {
+			Host string `arg:"host" default:"localhost"`
+		}
+		Expect(parser.ParseAndPrint(ctx, &data)).To(Succeed())
+		Expect(buf.String()).To(Equal("{\"Host\":\"localhost\"}\n"))
+	})
+})
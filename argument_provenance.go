@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+)
+
+// SourceKind is the kind of source a field value came from.
+type SourceKind string
+
+const (
+	// SourceKindDefault is the default tag of the field.
+	SourceKindDefault SourceKind = "default"
+	// SourceKindFile is the config file.
+	SourceKindFile SourceKind = "file"
+	// SourceKindEnv is an environment variable, including envFile.
+	SourceKindEnv SourceKind = "env"
+	// SourceKindFlag is a command-line argument.
+	SourceKindFlag SourceKind = "flag"
+	// SourceKindPositional is a positional command-line argument bound by the pos tag.
+	SourceKindPositional SourceKind = "positional"
+)
+
+// Source describes where the value of a field came from.
+type Source struct {
+	// Kind is the kind of the source.
+	Kind SourceKind
+	// Name is the flag name (without "-"), the env var name, the dotted config file key or
+	// the name of a positional argument (e.g. "SRC"). Name is empty for defaults.
+	Name string
+	// Raw is the string the value was converted from, before secret resolution.
+	// Flags given more than once are joined by the separator of the field,
+	// positional arguments of pos:"rest" by spaces.
+	// Raw is empty for fields with display:"hidden" or display:"length".
+	Raw string
+}
+
+// String returns the source as shown by Print (e.g. "env TIMEOUT" or "flag -timeout").
+func (s Source) String() string {
+	switch s.Kind {
+	case SourceKindDefault:
+		return string(s.Kind)
+	case SourceKindFlag:
+		return string(s.Kind) + " -" + s.Name
+	}
+	return string(s.Kind) + " " + s.Name
+}
+
+// Provenance maps the dotted path of every field set by parsing (e.g. "Kafka.Brokers")
+// to the source that won. Fields left at their zero value are missing.
+type Provenance map[string]Source
+
+// ParseWithReport works like Parse and additionally returns the source of every field value.
+// The Provenance is returned even if validation fails, so a wrong value can be traced
+// to its default, config file, env var or flag.
+//
+// Example:
+//
+//	provenance, err := argument.ParseWithReport(ctx, &config)
+//	if err != nil {
+//	    return err
+//	}
+//	log.Printf("timeout from %s", provenance["Timeout"])
+func ParseWithReport(ctx context.Context, data interface{}) (Provenance, error) {
+	return defaultParser.ParseWithReport(ctx, data)
+}
+
+// add stores the source of the field. Raw values of masked fields are dropped.
+func (p Provenance) add(f field, source Source) {
+	if p == nil {
+		return
+	}
+	switch f.display() {
+	case "hidden", "length":
+		source.Raw = ""
+	}
+	p[f.path] = source
+}
+
+// mergeProvenance merges the given provenances, later ones win.
+func mergeProvenance(list ...Provenance) Provenance {
+	result := make(Provenance)
+	for _, provenance := range list {
+		for k, v := range provenance {
+			result[k] = v
+		}
+	}
+	return result
+}
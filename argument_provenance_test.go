@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("ParseWithReport", func() {
+	type config struct {
+		Timeout  time.Duration     `arg:"timeout"  env:"TIMEOUT"  file:"timeout"  default:"10s"`
+		Host     string            `arg:"host"     env:"HOST"     file:"host"     default:"localhost"`
+		Port     int               `arg:"port"     env:"PORT"     file:"port"     default:"8080"`
+		Password string            `arg:"password" env:"PASSWORD"                 display:"length" envFile:"PASSWORD_FILE"`
+		Tags     []string          `arg:"tag"                                     repeat:"true"`
+		Labels   map[string]string `arg:"label"`
+		Name     string            `arg:"name"     env:"NAME"`
+		Required string            `arg:"required"                                required:"true"`
+	}
+	var ctx context.Context
+	var path string
+	BeforeEach(func() {
+		ctx = context.Background()
+		path = filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(path, []byte("host: example.com\nport: 9090\n"), 0600)).To(Succeed())
+	})
+	parse := func(args []string, environ []string) (argument.Provenance, config, error) {
+		var cfg config
+		parser := newTestParser(args, append(environ, "CONFIG_FILE="+path))
+		provenance, err := parser.ParseWithReport(ctx, &cfg)
+		return provenance, cfg, err
+	}
+	It("returns the source that won for every field", func() {
+		provenance, cfg, err := parse(
+			[]string{"-port=7070", "-tag=a", "-tag=b,c", "-label=x=1", "-label=y=2", "-required=yes"},
+			[]string{"TIMEOUT=30s", "PORT=6060", "PASSWORD=S3CR3T"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Port).To(Equal(7070))
+		Expect(provenance).To(Equal(argument.Provenance{
+			"Timeout":  {Kind: argument.SourceKindEnv, Name: "TIMEOUT", Raw: "30s"},
+			"Host":     {Kind: argument.SourceKindFile, Name: "host", Raw: "example.com"},
+			"Port":     {Kind: argument.SourceKindFlag, Name: "port", Raw: "7070"},
+			"Password": {Kind: argument.SourceKindEnv, Name: "PASSWORD"},
+			"Tags":     {Kind: argument.SourceKindFlag, Name: "tag", Raw: "a,b,c"},
+			"Labels":   {Kind: argument.SourceKindFlag, Name: "label", Raw: "x=1,y=2"},
+			"Required": {Kind: argument.SourceKindFlag, Name: "required", Raw: "yes"},
+		}))
+	})
+	It("reports defaults and env files", func() {
+		secretPath := filepath.Join(GinkgoT().TempDir(), "password")
+		Expect(os.WriteFile(secretPath, []byte("S3CR3T\n"), 0600)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("{}\n"), 0600)).To(Succeed())
+		provenance, _, err := parse(
+			[]string{"-required=yes"},
+			[]string{"PASSWORD_FILE=" + secretPath},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provenance["Timeout"]).To(Equal(argument.Source{
+			Kind: argument.SourceKindDefault,
+			Raw:  "10s",
+		}))
+		Expect(provenance["Password"]).To(Equal(argument.Source{
+			Kind: argument.SourceKindEnv,
+			Name: "PASSWORD_FILE",
+		}))
+		Expect(provenance).NotTo(HaveKey("Name"))
+	})
+	It("returns the provenance if validation fails", func() {
+		provenance, _, err := parse([]string{}, []string{"NAME=ben"})
+		Expect(err).To(HaveOccurred())
+		Expect(provenance["Name"]).To(Equal(argument.Source{
+			Kind: argument.SourceKindEnv,
+			Name: "NAME",
+			Raw:  "ben",
+		}))
+	})
+	It("formats sources", func() {
+		Expect(argument.Source{Kind: argument.SourceKindEnv, Name: "TIMEOUT"}.String()).
+			To(Equal("env TIMEOUT"))
+		Expect(argument.Source{Kind: argument.SourceKindFlag, Name: "timeout"}.String()).
+			To(Equal("flag -timeout"))
+		Expect(argument.Source{Kind: argument.SourceKindFile, Name: "kafka.brokers"}.String()).
+			To(Equal("file kafka.brokers"))
+		Expect(argument.Source{Kind: argument.SourceKindDefault}.String()).To(Equal("default"))
+	})
+	It("prints the source with ParseAndPrint", func() {
+		buf := &bytes.Buffer{}
+		cfg := struct {
+			Timeout  time.Duration `env:"TIMEOUT"  default:"10s"`
+			Password string        `env:"PASSWORD" display:"length"`
+			Name     string
+		}{}
+		Expect(newTestParser(
+			[]string{},
+			[]string{"TIMEOUT=30s", "PASSWORD=S3CR3T"},
+			argument.WithPrinter(argument.NewTextPrinter(buf)),
+		).ParseAndPrint(ctx, &cfg)).To(Succeed())
+		Expect(buf.String()).To(Equal("NAME      VALUE\n" +
+			"Timeout   30s (env TIMEOUT)\n" +
+			"Password  length 6 (env PASSWORD)\n" +
+			"Name      \n"))
+	})
+})
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o mocks/secret_resolver.go --fake-name SecretResolver . SecretResolver
+
+// SecretResolver resolves a secret reference into its value.
+// It is registered for a scheme with WithSecretResolver and applied to fields tagged secret:"true".
+type SecretResolver interface {
+	// Resolve returns the value of ref. ref is the reference without "scheme://"
+	// (e.g. "kv/app#password" for "vault://kv/app#password").
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretResolverFunc allows a function to be used as SecretResolver.
+type SecretResolverFunc func(ctx context.Context, ref string) (string, error)
+
+// Resolve calls f(ctx, ref).
+func (f SecretResolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// NewFileSecretResolver returns the built-in resolver of the file:// scheme.
+// It reads the file at ref and trims trailing newlines (e.g. file:///run/secrets/db).
+func NewFileSecretResolver() SecretResolver {
+	return SecretResolverFunc(func(ctx context.Context, ref string) (string, error) {
+		content, err := os.ReadFile(ref) // #nosec G304 -- path is provided by the operator
+		if err != nil {
+			return "", errors.Wrapf(ctx, err, "read secret file %s failed", ref)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	})
+}
+
+// NewEnvSecretResolver returns the built-in resolver of the env:// scheme.
+// It returns the value of the env var named by ref from environ (e.g. env://DB_PASSWORD).
+func NewEnvSecretResolver(environ []string) SecretResolver {
+	envValues := environMap(environ)
+	return SecretResolverFunc(func(ctx context.Context, ref string) (string, error) {
+		value, ok := envValues[ref]
+		if !ok {
+			return "", errors.Errorf(ctx, "secret env %s not found", ref)
+		}
+		return value, nil
+	})
+}
+
+// WithSecretResolver registers resolver for values starting with "scheme://".
+// It replaces the built-in file and env resolvers if scheme is "file" or "env".
+//
+// Example:
+//
+//	parser := argument.NewParser(
+//	    argument.WithSecretResolver("vault", vaultResolver),
+//	)
+func WithSecretResolver(scheme string, resolver SecretResolver) Option {
+	return func(p *parser) {
+		if p.secretResolvers == nil {
+			p.secretResolvers = make(secretResolvers)
+		}
+		p.secretResolvers[scheme] = resolver
+	}
+}
+
+// secretResolvers maps a scheme to its resolver.
+type secretResolvers map[string]SecretResolver
+
+// secretResolversFor returns the built-in resolvers plus the registered ones of the parser.
+func (p *parser) secretResolversFor(environ []string) secretResolvers {
+	result := secretResolvers{
+		"file": NewFileSecretResolver(),
+		"env":  NewEnvSecretResolver(environ),
+	}
+	for scheme, resolver := range p.secretResolvers {
+		result[scheme] = resolver
+	}
+	return result
+}
+
+// resolve returns the resolved value of a field tagged secret:"true".
+// Values of other fields, values without "scheme://" and values with an unregistered scheme
+// (e.g. a database URL) are returned unchanged.
+func (s secretResolvers) resolve(ctx context.Context, f field, value string) (string, error) {
+	if !f.secret {
+		return value, nil
+	}
+	scheme, ref, found := strings.Cut(value, "://")
+	if !found {
+		return value, nil
+	}
+	resolver, ok := s[scheme]
+	if !ok {
+		return value, nil
+	}
+	resolved, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return "", errors.Wrapf(ctx, err, "resolve %s secret of field %s failed", scheme, f.path)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+	"github.com/bborbe/argument/v2/mocks"
+)
+
+var _ = Describe("SecretResolver", func() {
+	type config struct {
+		Password string `arg:"password" env:"PASSWORD" secret:"true"`
+		Port     int    `arg:"port"     env:"PORT"     secret:"true" default:"vault://kv/app#port"`
+		DSN      string `arg:"dsn"      env:"DSN"      secret:"true"`
+		Plain    string `arg:"plain"    env:"PLAIN"`
+	}
+	var ctx context.Context
+	var vault *mocks.SecretResolver
+	var options []argument.Option
+	BeforeEach(func() {
+		ctx = context.Background()
+		vault = &mocks.SecretResolver{}
+		options = []argument.Option{argument.WithSecretResolver("vault", vault)}
+		vault.ResolveStub = func(ctx context.Context, ref string) (string, error) {
+			switch ref {
+			case "kv/app#password":
+				return "S3CR3T", nil
+			case "kv/app#port":
+				return "8080", nil
+			}
+			return "", errors.Errorf(ctx, "secret %s not found", ref)
+		}
+	})
+	It("resolves args, env and defaults before conversion", func() {
+		var cfg config
+		Expect(newTestParser(
+			[]string{"-password=vault://kv/app#password"},
+			[]string{"DSN=postgres://user@localhost/db"},
+			options...,
+		).Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Password).To(Equal("S3CR3T"))
+		Expect(cfg.Port).To(Equal(8080))
+		Expect(cfg.DSN).To(Equal("postgres://user@localhost/db"))
+		Expect(vault.ResolveCallCount()).To(Equal(2))
+	})
+	It("does not resolve overridden defaults", func() {
+		var cfg struct {
+			Password string `arg:"password" secret:"true" default:"vault://kv/missing"`
+		}
+		Expect(newTestParser(
+			[]string{"-password=given"},
+			[]string{},
+			options...,
+		).Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Password).To(Equal("given"))
+		Expect(vault.ResolveCallCount()).To(Equal(0))
+	})
+	It("does not resolve fields without secret tag", func() {
+		var cfg config
+		Expect(newTestParser(
+			[]string{"-plain=vault://kv/app#password"},
+			[]string{},
+			options...,
+		).Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Plain).To(Equal("vault://kv/app#password"))
+	})
+	It("resolves the built-in env scheme", func() {
+		var cfg config
+		Expect(newTestParser(
+			[]string{},
+			[]string{"PASSWORD=env://DB_PASSWORD", "DB_PASSWORD=fromenv"},
+			options...,
+		).Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Password).To(Equal("fromenv"))
+	})
+	It("resolves the built-in file scheme", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "password")
+		Expect(os.WriteFile(path, []byte("fromfile\n"), 0600)).To(Succeed())
+		var cfg config
+		Expect(newTestParser(
+			[]string{},
+			[]string{"PASSWORD=file://" + path},
+			options...,
+		).Parse(ctx, &cfg)).To(Succeed())
+		Expect(cfg.Password).To(Equal("fromfile"))
+	})
+	It("returns the error of the resolver", func() {
+		var cfg config
+		parser := newTestParser([]string{"-password=vault://kv/missing"}, []string{}, options...)
+		err := parser.Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("resolve vault secret of field Password failed"))
+	})
+	It("returns error for missing env of the env scheme", func() {
+		var cfg config
+		parser := newTestParser([]string{}, []string{"PASSWORD=env://MISSING"}, options...)
+		err := parser.Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("secret env MISSING not found"))
+	})
+	It("prints secret fields with length by default", func() {
+		printer := &mocks.Printer{}
+		cfg := struct {
+			Password string `secret:"true"`
+		}{
+			Password: "S3CR3T",
+		}
+		Expect(argument.PrintWith(ctx, &cfg, printer)).To(Succeed())
+		_, fields := printer.PrintArgsForCall(0)
+		Expect(fields).To(Equal([]argument.PrintField{
+			{Name: "Password", Masked: true, Length: 6},
+		}))
+	})
+})
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+// ErrUnknownEnv is the cause of the error returned for env vars rejected by WithStrictEnv.
+var ErrUnknownEnv = stderrors.New("unknown env var")
+
+// maxSuggestionDistance is the largest edit distance of a known env var suggested for an
+// unknown one.
+const maxSuggestionDistance = 3
+
+// WithStrictEnv rejects env vars that start with prefix (e.g. "MYAPP_") but belong to no
+// field, so typos like MYAPP_KAFAK_BROKERS are noticed. The error names every unknown env var
+// and the closest known one (e.g. "MYAPP_KAFAK_BROKERS (did you mean MYAPP_KAFKA_BROKERS?)")
+// and has ErrUnknownEnv as cause.
+//
+// Known are the env, envAlias and envFile tags and CONFIG_FILE.
+// The check runs in Parse, ParseAndPrint, ParseWithReport, ParseOnly, ParseEnv, Dispatch
+// (against the global and the command struct) and NewWatcher.
+func WithStrictEnv(prefix string) Option {
+	return func(p *parser) {
+		p.strictEnvPrefix = prefix
+		p.strictEnvWarning = false
+	}
+}
+
+// WithStrictEnvWarning works like WithStrictEnv, but logs a warning for every unknown env var
+// through the logger of WithLogger instead of returning an error.
+func WithStrictEnvWarning(prefix string) Option {
+	return func(p *parser) {
+		p.strictEnvPrefix = prefix
+		p.strictEnvWarning = true
+	}
+}
+
+// checkUnknownEnv reports env vars with the prefix of WithStrictEnv that belong to none of the
+// fields of the given structs.
+func (p *parser) checkUnknownEnv(ctx context.Context, environ []string, data ...interface{}) error {
+	if p.strictEnvPrefix == "" {
+		return nil
+	}
+	known, err := knownEnvNames(p.fieldOptions(), data...)
+	if err != nil {
+		return err
+	}
+	var unknown []string
+	for name := range environMap(environ) {
+		if strings.HasPrefix(name, p.strictEnvPrefix) && !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	descriptions := make([]string, len(unknown))
+	for i, name := range unknown {
+		descriptions[i] = name
+		if suggestion := closestName(name, known); suggestion != "" {
+			descriptions[i] = fmt.Sprintf("%s (did you mean %s?)", name, suggestion)
+		}
+		if p.strictEnvWarning {
+			p.log().WarnContext(ctx, "unknown env "+descriptions[i], slog.String("env", name))
+		}
+	}
+	if p.strictEnvWarning {
+		return nil
+	}
+	return errors.Wrap(ctx, ErrUnknownEnv, strings.Join(descriptions, ", "))
+}
+
+// knownEnvNames returns the names of all env vars read for the fields of the given structs.
+func knownEnvNames(options fieldOptions, data ...interface{}) (map[string]bool, error) {
+	result := map[string]bool{configFileEnvName: true}
+	for _, d := range data {
+		if err := walkFieldsWith(d, options, func(f field) error {
+			if f.hasEnv {
+				for _, name := range f.envNames() {
+					result[name] = true
+				}
+			}
+			if f.hasEnvFile {
+				result[f.envFileName] = true
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// closestName returns the known name with the smallest edit distance to name, or "" if no
+// known name is within maxSuggestionDistance. Ties are broken alphabetically.
+func closestName(name string, known map[string]bool) string {
+	var result string
+	best := maxSuggestionDistance + 1
+	for candidate := range known {
+		distance := editDistance(name, candidate)
+		if distance < best || distance == best && candidate < result {
+			result = candidate
+			best = distance
+		}
+	}
+	if best > maxSuggestionDistance {
+		return ""
+	}
+	return result
+}
+
+// editDistance returns the Levenshtein distance of a and b counting a swap of two adjacent
+// characters as one edit, the most common typo.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// rows[i][j] is the distance of ra[:i] and rb[:j]
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("Strict env", func() {
+	type kafka struct {
+		Brokers string `env:"BROKERS" envAlias:"BROKER"`
+	}
+	type config struct {
+		Kafka    kafka  `env:"MYAPP_KAFKA_"`
+		Port     int    `env:"MYAPP_PORT"`
+		Password string `env:"MYAPP_PASSWORD" envFile:"MYAPP_PASSWORD_FILE"`
+	}
+	var ctx context.Context
+	var logs *bytes.Buffer
+	var cfg config
+	parse := func(environ []string, opts ...argument.Option) error {
+		opts = append([]argument.Option{
+			argument.WithLogger(slog.New(slog.NewTextHandler(logs, nil))),
+		}, opts...)
+		return newTestParser(nil, environ, opts...).ParseOnly(ctx, &cfg)
+	}
+	BeforeEach(func() {
+		ctx = context.Background()
+		logs = &bytes.Buffer{}
+		cfg = config{}
+	})
+	It("accepts known env vars and env vars without the prefix", func() {
+		Expect(parse([]string{
+			"MYAPP_KAFKA_BROKERS=kafka:9092",
+			"MYAPP_KAFKA_BROKER=kafka:9092",
+			"MYAPP_PASSWORD_FILE=/dev/null",
+			"CONFIG_FILE=",
+			"KAFAK_BROKERS=other",
+		}, argument.WithStrictEnv("MYAPP_"))).To(Succeed())
+		Expect(cfg.Kafka.Brokers).To(Equal("kafka:9092"))
+	})
+	It("accepts values containing =", func() {
+		Expect(parse([]string{
+			"MYAPP_KAFKA_BROKERS=kafka:9092?opt=a=b",
+			"MYAPP_PASSWORD=c2VjcmV0==",
+		}, argument.WithStrictEnv("MYAPP_"))).To(Succeed())
+		Expect(cfg.Kafka.Brokers).To(Equal("kafka:9092?opt=a=b"))
+		Expect(cfg.Password).To(Equal("c2VjcmV0=="))
+	})
+	It("rejects unknown env vars with the prefix and suggests the closest name", func() {
+		err := parse(
+			[]string{"MYAPP_KAFAK_BROKERS=kafka:9092", "MYAPP_PROT=80", "MYAPP_UNRELATED=1"},
+			argument.WithStrictEnv("MYAPP_"),
+		)
+		Expect(err).To(HaveOccurred())
+		Expect(errors.Is(err, argument.ErrUnknownEnv)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring(
+			"MYAPP_KAFAK_BROKERS (did you mean MYAPP_KAFKA_BROKERS?), " +
+				"MYAPP_PROT (did you mean MYAPP_PORT?), MYAPP_UNRELATED: unknown env var",
+		))
+	})
+	It("warns instead of failing with WithStrictEnvWarning", func() {
+		Expect(parse(
+			[]string{"MYAPP_PROT=80"},
+			argument.WithStrictEnvWarning("MYAPP_"),
+		)).To(Succeed())
+		Expect(logs.String()).To(ContainSubstring(
+			`level=WARN msg="unknown env MYAPP_PROT (did you mean MYAPP_PORT?)" env=MYAPP_PROT`,
+		))
+	})
+	It("ignores unknown env vars by default", func() {
+		Expect(parse([]string{"MYAPP_PROT=80"})).To(Succeed())
+	})
+	It("checks ParseEnv", func() {
+		parser := argument.NewParser(argument.WithStrictEnv("MYAPP_"))
+		err := parser.ParseEnv(ctx, &cfg, []string{"MYAPP_PORTT=80"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("did you mean MYAPP_PORT?"))
+	})
+	It("checks Dispatch against the global and the command struct", func() {
+		var global struct {
+			Debug bool `env:"MYAPP_DEBUG"`
+		}
+		run := argument.NewCommand("run", "", func(ctx context.Context, cfg *config) error {
+			return nil
+		})
+		args := []string{"run"}
+		strict := argument.WithStrictEnv("MYAPP_")
+		parser := newTestParser(args, []string{"MYAPP_DEBUG=true", "MYAPP_PORT=80"}, strict)
+		Expect(parser.Dispatch(ctx, &global, run)).To(Succeed())
+		parser = newTestParser(args, []string{"MYAPP_DEBGU=true"}, strict)
+		err := parser.Dispatch(ctx, &global, run)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("MYAPP_DEBGU (did you mean MYAPP_DEBUG?)"))
+	})
+})
@@ -5,12 +5,15 @@
 package argument_test
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/format"
+
+	"github.com/bborbe/argument/v2"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6@v6.12.2 -generate
@@ -20,3 +23,19 @@ func TestSuite(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Test Suite")
 }
+
+// testOptions returns the options of a Parser that reads args and environ instead of os.Args
+// and os.Environ and discards its output. opts are applied afterwards, so WithOutput replaces
+// the output.
+func testOptions(args []string, environ []string, opts ...argument.Option) []argument.Option {
+	return append([]argument.Option{
+		argument.WithArgs(args),
+		argument.WithEnviron(environ),
+		argument.WithOutput(&bytes.Buffer{}),
+	}, opts...)
+}
+
+// newTestParser returns a Parser created with testOptions.
+func newTestParser(args []string, environ []string, opts ...argument.Option) argument.Parser {
+	return argument.NewParser(testOptions(args, environ, opts...)...)
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/bborbe/errors"
+)
+
+// ErrHelp is returned by the methods of a Parser from NewParser if -h or -help was given.
+// The package-level functions use flag.CommandLine with flag.ExitOnError and exit the
+// process with status 0 instead. The usage has already been written to the output at that
+// point, so callers usually exit with status 0:
+//
+//	err := argument.NewParser().Parse(ctx, &config)
+//	if errors.Is(err, argument.ErrHelp) {
+//	    os.Exit(0)
+//	}
+var ErrHelp = flag.ErrHelp
+
+// Usage writes a table of all arguments of data to w.
+// Each row lists flag, env var, type, default, required marker and the usage tag.
+// Positional fields show their name (e.g. SRC or FILES...) in the flag column.
+// Fields of nested structs are grouped by their field path.
+// Defaults of fields with display:"hidden" or display:"length" are not shown.
+//
+// Parse calls Usage automatically if -h or -help is given.
+//
+// Example output:
+//
+//	Options:
+//	  FLAG       ENV       TYPE      DEFAULT    REQUIRED  USAGE
+//	  -host      HOST      string    localhost            server host
+//	  -password  PASSWORD  string    <hidden>   yes       database password
+//
+//	Kafka:
+//	  FLAG            ENV            TYPE                DEFAULT  REQUIRED  USAGE
+//	  -kafka-brokers  KAFKA_BROKERS  []string (sep ",")           yes       kafka brokers
+func Usage(w io.Writer, data interface{}) error {
+	return usage(w, data, fieldOptions{})
+}
+
+// usage works like Usage with the field names of WithEnvPrefix and WithNaming.
+func usage(w io.Writer, data interface{}, options fieldOptions) error {
+	ctx := context.Background()
+	var rows []usageRow
+	if err := walkFieldsWith(data, options, func(f field) error {
+		if f.hasArg || f.hasPos || f.hasEnv || f.hasEnvFile {
+			rows = append(rows, newUsageRow(f))
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(ctx, err, "walk fields failed")
+	}
+	if hasFileTag(data) {
+		rows = append([]usageRow{{
+			flag:  "-" + configFileArgName,
+			env:   configFileEnvName,
+			kind:  "string",
+			usage: "path to config file (JSON or YAML)",
+		}}, rows...)
+	}
+
+	// Rows are grouped by the order in which each group appears first
+	var groups []string
+	rowsByGroup := make(map[string][]usageRow)
+	for _, row := range rows {
+		if _, ok := rowsByGroup[row.group]; !ok {
+			groups = append(groups, row.group)
+		}
+		rowsByGroup[row.group] = append(rowsByGroup[row.group], row)
+	}
+
+	buf := &bytes.Buffer{}
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(buf)
+		}
+		tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "%s:\n", usageGroupTitle(group))
+		fmt.Fprintln(tw, "  FLAG\tENV\tTYPE\tDEFAULT\tREQUIRED\tUSAGE")
+		for _, row := range rowsByGroup[group] {
+			fmt.Fprintf(
+				tw,
+				"  %s\t%s\t%s\t%s\t%s\t%s\n",
+				row.flag,
+				row.env,
+				row.kind,
+				row.defaultValue,
+				row.required,
+				row.usage,
+			)
+		}
+		if err := tw.Flush(); err != nil {
+			return errors.Wrap(ctx, err, "flush usage failed")
+		}
+	}
+
+	// tabwriter pads empty trailing cells, which is noise on a terminal
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if line == "" {
+			continue
+		}
+		if _, err := io.WriteString(w, strings.TrimRight(line, " \n")+"\n"); err != nil {
+			return errors.Wrap(ctx, err, "write usage failed")
+		}
+	}
+	return nil
+}
+
+// usageRow is a single line of the Usage table.
+type usageRow struct {
+	group        string
+	flag         string
+	env          string
+	kind         string
+	defaultValue string
+	required     string
+	usage        string
+}
+
+func newUsageRow(f field) usageRow {
+	tf := f.structField
+	row := usageRow{
+		kind:  f.value.Type().String(),
+		usage: tf.Tag.Get("usage"),
+	}
+	if index := strings.LastIndex(f.path, "."); index >= 0 {
+		row.group = f.path[:index]
+	}
+	if f.hasArg {
+		row.flag = "-" + f.argName
+		// Short flags only work with WithGNUFlags, which takes long names with two dashes
+		if short, ok := tf.Tag.Lookup("short"); ok {
+			row.flag = fmt.Sprintf("-%s, --%s", short, f.argName)
+		}
+	}
+	if f.hasPos {
+		row.flag = f.posName()
+	}
+	if f.hasEnv {
+		row.env = f.envName
+	}
+	if f.hasEnvFile {
+		envFile := f.envFileName + " (file)"
+		if row.env != "" {
+			envFile = row.env + ", " + envFile
+		}
+		row.env = envFile
+	}
+	// Types with a converter like net.IP are not split
+	_, hasConverter := LookupConverter(valueType(f))
+	if !hasConverter && f.value.Kind() == reflect.Map {
+		separator, kvSeparator := mapSeparators(tf)
+		row.kind = fmt.Sprintf("%s (sep %q, kv %q)", row.kind, separator, kvSeparator)
+	}
+	if !hasConverter && f.value.Kind() == reflect.Slice {
+		separator := tf.Tag.Get("separator")
+		if separator == "" {
+			separator = ","
+		}
+		if tf.Tag.Get("repeat") == "true" {
+			row.kind = fmt.Sprintf("%s (sep %q, repeatable)", row.kind, separator)
+		} else {
+			row.kind = fmt.Sprintf("%s (sep %q)", row.kind, separator)
+		}
+	}
+	if defaultValue, ok := tf.Tag.Lookup("default"); ok && defaultValue != "" {
+		switch f.display() {
+		case "hidden", "length":
+			row.defaultValue = "<hidden>"
+		default:
+			row.defaultValue = defaultValue
+		}
+	}
+	if tf.Tag.Get("required") == "true" {
+		row.required = "yes"
+	}
+	return row
+}
+
+func usageGroupTitle(group string) string {
+	if group == "" {
+		return "Options"
+	}
+	return group
+}
+
+// setUsage replaces the usage of the flag set with the Usage table of data.
+func setUsage(flagSet *flag.FlagSet, data interface{}, options fieldOptions) {
+	flagSet.Usage = func() {
+		output := flagSet.Output()
+		fmt.Fprintf(output, "Usage of %s:\n\n", flagSet.Name())
+		_ = usage(output, data, options)
+	}
+}
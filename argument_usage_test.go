@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("Usage", func() {
+	type kafka struct {
+		Brokers []string `arg:"brokers" env:"BROKERS" required:"true" usage:"kafka brokers" separator:";"`
+	}
+	type config struct {
+		Host     string        `arg:"host"     env:"HOST"     default:"localhost" usage:"server host"`
+		Password string        `arg:"password" env:"PASSWORD" default:"secret"    usage:"database password" required:"true" display:"length"`
+		Token    string        `               env:"TOKEN"    default:"token"                                               display:"hidden"`
+		Timeout  time.Duration `arg:"timeout"                 default:"1m"`
+		Kafka    kafka         `arg:"kafka-"   env:"KAFKA_"`
+		Ignored  string
+	}
+	var buf *bytes.Buffer
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+	})
+	It("renders a grouped table", func() {
+		var cfg config
+		Expect(argument.Usage(buf, &cfg)).To(Succeed())
+		Expect(buf.String()).To(Equal(`Options:
+  FLAG       ENV       TYPE           DEFAULT    REQUIRED  USAGE
+  -host      HOST      string         localhost            server host
+  -password  PASSWORD  string         <hidden>   yes       database password
+             TOKEN     string         <hidden>
+  -timeout             time.Duration  1m
+
+Kafka:
+  FLAG            ENV            TYPE                DEFAULT  REQUIRED  USAGE
+  -kafka-brokers  KAFKA_BROKERS  []string (sep ";")           yes       kafka brokers
+`))
+	})
+	It("lists the config file argument", func() {
+		var cfg struct {
+			Name string `arg:"name" file:"name"`
+		}
+		Expect(argument.Usage(buf, &cfg)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("-config"))
+		Expect(buf.String()).To(ContainSubstring("CONFIG_FILE"))
+	})
+	It("is printed by Parse for -help", func() {
+		parser := newTestParser(
+			[]string{"-help"},
+			[]string{},
+			argument.WithName("myapp"),
+			argument.WithOutput(buf),
+		)
+		var cfg config
+		err := parser.Parse(context.Background(), &cfg)
+		Expect(errors.Is(err, argument.ErrHelp)).To(BeTrue())
+		Expect(buf.String()).To(HavePrefix("Usage of myapp:\n\nOptions:\n"))
+		Expect(buf.String()).To(ContainSubstring("-kafka-brokers  KAFKA_BROKERS"))
+		Expect(buf.String()).NotTo(ContainSubstring("secret"))
+	})
+	It("is printed by Parse for -h", func() {
+		parser := newTestParser([]string{"-h"}, []string{}, argument.WithOutput(buf))
+		var cfg config
+		err := parser.Parse(context.Background(), &cfg)
+		Expect(errors.Is(err, argument.ErrHelp)).To(BeTrue())
+		Expect(buf.String()).To(ContainSubstring("-host"))
+	})
+	It("lists the envFile env", func() {
+		var cfg struct {
+			Password string `env:"PASSWORD" envFile:"PASSWORD_PATH" default:"secret"`
+		}
+		Expect(argument.Usage(buf, &cfg)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("PASSWORD, PASSWORD_PATH (file)"))
+		Expect(buf.String()).To(ContainSubstring("<hidden>"))
+		Expect(buf.String()).NotTo(ContainSubstring("secret"))
+	})
+})
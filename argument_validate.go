@@ -9,176 +9,111 @@ import (
 	"context"
 	"fmt"
 	"reflect"
-	"time"
 
 	"github.com/bborbe/errors"
 )
 
-func handleCustomTypeValidation(
-	ctx context.Context,
-	tf reflect.StructField,
-	ef reflect.Value,
-	createError func() error,
-) (bool, error) {
-	// Get the underlying type
-	underlyingType := ef.Type()
-	for underlyingType.Kind() == reflect.Pointer {
-		underlyingType = underlyingType.Elem()
-	}
-
-	// Check if it's a named type (custom type) with an underlying primitive type
-	if underlyingType.PkgPath() != "" && underlyingType.Kind() != reflect.Struct {
-		switch underlyingType.Kind() {
-		case reflect.String:
-			// For custom string types, check if value equals zero value of underlying type
-			zeroValue := reflect.Zero(underlyingType).Interface()
-			if ef.Interface() == zeroValue {
-				return true, createError()
-			}
-			return true, nil
-		case reflect.Bool:
-			// Bool types are never considered "empty" for required validation
-			return true, nil
-		case reflect.Int, reflect.Int32, reflect.Int64:
-			// For custom int types, check if value equals zero value of underlying type
-			zeroValue := reflect.Zero(underlyingType).Interface()
-			if ef.Interface() == zeroValue {
-				return true, createError()
-			}
-			return true, nil
-		case reflect.Uint, reflect.Uint64:
-			// For custom uint types, check if value equals zero value of underlying type
-			zeroValue := reflect.Zero(underlyingType).Interface()
-			if ef.Interface() == zeroValue {
-				return true, createError()
-			}
-			return true, nil
-		case reflect.Float64:
-			// For custom float64 types, check if value equals zero value of underlying type
-			zeroValue := reflect.Zero(underlyingType).Interface()
-			if ef.Interface() == zeroValue {
-				return true, createError()
-			}
-			return true, nil
-		}
-	}
-	return false, nil
-}
-
 // ValidateRequired fields are set and returns an error if not.
+// Fields of nested structs are validated as well.
+// All empty required fields are reported together as ValidationErrors with ErrRequired as cause.
+//
+// Cross-field constraints are checked as well. Fields are referenced by their Go field name
+// relative to the same struct, or by their dotted path:
+//   - requiredIf:"Mode=prod|staging": required if the field Mode has one of the values
+//   - requiredWith:"TLSCert,TLSCA": required if any of the fields is set
+//   - excludes:"Force": Force must not be set if this field is set
+//   - exclusive:"output": at most one field with the same group name may be set
+//
+// Bools count as set if true. Conflicts are reported with ErrConflict as cause.
 func ValidateRequired(ctx context.Context, data interface{}) error {
-	e := reflect.ValueOf(data).Elem()
-	t := e.Type()
-	for i := 0; i < e.NumField(); i++ {
-		tf := t.Field(i)
-		ef := e.Field(i)
-		argName, ok := tf.Tag.Lookup("required")
-		if !ok || argName != "true" {
-			continue
+	return validateRequired(ctx, data, fieldOptions{})
+}
+
+// validateRequired works like ValidateRequired with the field names of WithEnvPrefix and WithNaming.
+func validateRequired(ctx context.Context, data interface{}, options fieldOptions) error {
+	var fields []field
+	index := make(fieldIndex)
+	if err := walkFieldsWith(data, options, func(f field) error {
+		fields = append(fields, f)
+		index[f.path] = f
+		return nil
+	}); err != nil {
+		return err
+	}
+	var validationErrors ValidationErrors
+	for _, f := range fields {
+		if required, ok := f.structField.Tag.Lookup("required"); ok && required == "true" {
+			err := validateRequiredField(ctx, f)
+			var validationError *ValidationError
+			if errors.As(err, &validationError) {
+				validationErrors = append(validationErrors, validationError)
+			} else if err != nil {
+				return err
+			}
 		}
-		if err := validateRequiredField(ctx, tf, ef); err != nil {
+		constraintErrors, err := validateConstraints(ctx, f, index)
+		if err != nil {
 			return err
 		}
+		validationErrors = append(validationErrors, constraintErrors...)
 	}
-	return nil
+	groupErrors, err := validateExclusiveGroups(ctx, fields)
+	if err != nil {
+		return err
+	}
+	validationErrors = append(validationErrors, groupErrors...)
+	return validationErrors.errorOrNil()
 }
 
 // validateRequiredField checks if a single required field is set.
-func validateRequiredField(ctx context.Context, tf reflect.StructField, ef reflect.Value) error {
-	createError := func() error {
-		buf := bytes.NewBufferString("Required field empty, ")
-		argName, argOk := tf.Tag.Lookup("arg")
-		if argOk {
-			fmt.Fprintf(buf, "define parameter %s", argName)
-		}
-		envName, envOk := tf.Tag.Lookup("env")
-		if envOk {
-			if argOk {
-				fmt.Fprintf(buf, " or ")
-			}
-			fmt.Fprintf(buf, "define env %s", envName)
-		}
-		return errors.New(ctx, buf.String())
+func validateRequiredField(ctx context.Context, f field) error {
+	empty, err := isEmpty(ctx, f)
+	if err != nil {
+		return err
 	}
+	if empty {
+		return newValidationError(f, requiredReason(f), ErrRequired)
+	}
+	return nil
+}
 
-	switch ef.Interface().(type) {
-	case string:
-		var empty string
-		if empty == ef.Interface() {
-			return createError()
-		}
-	case bool:
-	case int:
-		var empty int
-		if empty == ef.Interface() {
-			return createError()
-		}
-	case int64:
-		var empty int64
-		if empty == ef.Interface() {
-			return createError()
-		}
-	case uint:
-		var empty uint
-		if empty == ef.Interface() {
-			return createError()
-		}
-	case uint64:
-		var empty uint64
-		if empty == ef.Interface() {
-			return createError()
-		}
-	case int32:
-		var empty int32
-		if empty == ef.Interface() {
-			return createError()
-		}
-	case float64:
-		var empty float64
-		if empty == ef.Interface() {
-			return createError()
-		}
-	case *float64:
-		var empty *float64
-		if empty == ef.Interface() {
-			return createError()
-		}
-	case time.Duration:
-		var empty time.Duration
-		if empty == ef.Interface() {
-			return createError()
-		}
-	default:
-		// Handle pointers
-		if ef.Kind() == reflect.Pointer {
-			if ef.IsNil() {
-				return createError()
-			}
-		} else if ef.Kind() == reflect.Slice {
-			// Handle slices
-			if ef.Len() == 0 {
-				return createError()
-			}
-		} else {
-			// Check if it's a custom type with underlying primitive type
-			if handled, err := handleCustomTypeValidation(ctx, tf, ef, createError); handled {
-				if err != nil {
-					return err
-				}
-			} else {
-				return errors.Errorf(ctx, "field %s with type %T is unsupported", tf.Name, ef.Interface())
-			}
+// requiredReason describes how to set an empty required field
+// (e.g. "Required field empty, define parameter port or define env PORT").
+func requiredReason(f field) string {
+	buf := bytes.NewBufferString("Required field empty, ")
+	if f.hasArg {
+		fmt.Fprintf(buf, "define parameter %s", f.argName)
+	}
+	if f.hasPos {
+		fmt.Fprintf(buf, "define argument %s", f.posName())
+	}
+	if f.hasEnv {
+		if f.hasArg || f.hasPos {
+			fmt.Fprintf(buf, " or ")
 		}
+		fmt.Fprintf(buf, "define env %s", f.envName)
 	}
-	return nil
+	return buf.String()
 }
 
 // ValidateHasValidation validates data using the HasValidation interface.
 // It first checks if the top-level struct implements HasValidation.
 // Then it iterates through struct fields:
+//   - For nested structs: validates the struct itself, then recurses into its fields
 //   - For slices: validates the slice type first, then falls back to validating each element
 //   - For other types: validates if they implement HasValidation
 //
+// Fields are also checked against their validation tags:
+//   - min, max: numbers by value (limits use the field syntax, e.g. "1s"), strings, slices
+//     and maps by length
+//   - len: exact length of strings, slices and maps
+//   - oneof: value (or every slice element) is one of the options separated by "|"
+//   - pattern: value (or every slice element) matches the regexp
+//   - nonempty: strings, slices and maps are not empty
+//
+// Failures of tags are reported with ErrInvalid as cause and name the flag and env var
+// (e.g. "Invalid field, parameter port or env PORT: value must be at most 65535, got 70000").
+//
 // Important: ValidateHasValidation runs on ALL fields that implement HasValidation,
 // regardless of whether they have the `required:"true"` tag. This is by design:
 //   - The `required` tag checks if a field is present (non-zero)
@@ -198,7 +133,7 @@ func validateRequiredField(ctx context.Context, tf reflect.StructField, ef refle
 //	    return nil
 //	}
 //
-// Returns the first validation error encountered.
+// All validation failures are collected and returned together as ValidationErrors.
 //
 // Example usage (automatic validation via Parse):
 //
@@ -249,15 +184,45 @@ func validateRequiredField(ctx context.Context, tf reflect.StructField, ef refle
 //	    return nil
 //	}
 func ValidateHasValidation(ctx context.Context, data interface{}) error {
+	return validateHasValidation(ctx, data, fieldOptions{})
+}
+
+// validateHasValidation works like ValidateHasValidation with the field names of WithEnvPrefix and WithNaming.
+func validateHasValidation(ctx context.Context, data interface{}, options fieldOptions) error {
+	var validationErrors ValidationErrors
+
 	// First, check if the top-level struct implements HasValidation
 	if validator, ok := data.(HasValidation); ok {
 		if err := validator.Validate(ctx); err != nil {
-			return errors.Wrap(ctx, err, "validation failed")
+			validationErrors = append(validationErrors, &ValidationError{
+				Reason: "validation failed",
+				Err:    err,
+			})
 		}
 	}
 
 	// Now validate fields
-	e := reflect.ValueOf(data).Elem()
+	fieldErrors, err := validateStructFields(
+		ctx,
+		rootPrefix(data, options),
+		reflect.ValueOf(data).Elem(),
+	)
+	if err != nil {
+		return err
+	}
+	validationErrors = append(validationErrors, fieldErrors...)
+	return validationErrors.errorOrNil()
+}
+
+// validateStructFields validates all fields of the given struct value.
+// Nested structs are validated as a whole first and then field by field.
+// Leaf fields are validated by HasValidation and their validation tags.
+func validateStructFields(
+	ctx context.Context,
+	prefix fieldPrefix,
+	e reflect.Value,
+) (ValidationErrors, error) {
+	var validationErrors ValidationErrors
 	t := e.Type()
 	for i := 0; i < e.NumField(); i++ {
 		ef := e.Field(i)
@@ -268,19 +233,48 @@ func ValidateHasValidation(ctx context.Context, data interface{}) error {
 			continue
 		}
 
-		if err := validateField(ctx, tf.Name, ef); err != nil {
-			return err
+		if !isNestedStruct(tf.Type) {
+			f := prefix.leaf(tf, ef)
+			validationErrors = append(validationErrors, validateField(ctx, f)...)
+			tagErrors, err := validateTags(ctx, f)
+			if err != nil {
+				return nil, err
+			}
+			validationErrors = append(validationErrors, tagErrors...)
+			continue
 		}
-	}
 
-	return nil
+		// Nested structs are validated like the top-level struct, so pointer receivers work
+		structValue := ef
+		if structValue.Kind() != reflect.Pointer && structValue.CanAddr() {
+			structValue = structValue.Addr()
+		}
+		validationErrors = append(validationErrors, validateField(ctx, field{
+			path:  joinPath(prefix.path, tf.Name),
+			value: structValue,
+		})...)
+		if ef.Kind() == reflect.Pointer {
+			if ef.IsNil() {
+				continue
+			}
+			ef = ef.Elem()
+		}
+		nestedErrors, err := validateStructFields(ctx, prefix.nested(tf), ef)
+		if err != nil {
+			return nil, err
+		}
+		validationErrors = append(validationErrors, nestedErrors...)
+	}
+	return validationErrors, nil
 }
 
 // validateField validates a single field that may implement HasValidation.
-func validateField(ctx context.Context, fieldName string, fieldValue reflect.Value) error {
+func validateField(ctx context.Context, f field) ValidationErrors {
+	fieldValue := f.value
+
 	// Handle slices specially
 	if fieldValue.Kind() == reflect.Slice {
-		return validateSlice(ctx, fieldName, fieldValue)
+		return validateSlice(ctx, f)
 	}
 
 	// For non-slice types, check if they implement HasValidation
@@ -292,13 +286,11 @@ func validateField(ctx context.Context, fieldName string, fieldValue reflect.Val
 
 		if validator, ok := fieldValue.Interface().(HasValidation); ok {
 			if err := validator.Validate(ctx); err != nil {
-				return errors.Wrapf(
-					ctx,
+				return ValidationErrors{newValidationError(
+					f,
+					fmt.Sprintf("field %s (type %s) validation failed", f.path, fieldValue.Type()),
 					err,
-					"field %s (type %s) validation failed",
-					fieldName,
-					fieldValue.Type(),
-				)
+				)}
 			}
 		}
 	}
@@ -309,41 +301,44 @@ func validateField(ctx context.Context, fieldName string, fieldValue reflect.Val
 // validateSlice validates a slice field.
 // First checks if the slice type itself implements HasValidation.
 // Falls back to validating each element if they implement HasValidation.
-func validateSlice(ctx context.Context, fieldName string, sliceValue reflect.Value) error {
+func validateSlice(ctx context.Context, f field) ValidationErrors {
+	sliceValue := f.value
+
 	// First, check if the slice itself implements HasValidation
 	if sliceValue.CanInterface() {
 		if validator, ok := sliceValue.Interface().(HasValidation); ok {
 			if err := validator.Validate(ctx); err != nil {
-				return errors.Wrapf(
-					ctx,
+				return ValidationErrors{newValidationError(
+					f,
+					fmt.Sprintf("field %s (type %s) validation failed", f.path, sliceValue.Type()),
 					err,
-					"field %s (type %s) validation failed",
-					fieldName,
-					sliceValue.Type(),
-				)
+				)}
 			}
 			return nil
 		}
 	}
 
 	// Fallback: validate each element
+	var validationErrors ValidationErrors
 	for i := 0; i < sliceValue.Len(); i++ {
 		elem := sliceValue.Index(i)
 		if elem.CanInterface() {
 			if validator, ok := elem.Interface().(HasValidation); ok {
 				if err := validator.Validate(ctx); err != nil {
-					return errors.Wrapf(
-						ctx,
+					validationErrors = append(validationErrors, newValidationError(
+						f,
+						fmt.Sprintf(
+							"field %s[%d] (type %s) validation failed",
+							f.path,
+							i,
+							elem.Type(),
+						),
 						err,
-						"field %s[%d] (type %s) validation failed",
-						fieldName,
-						i,
-						elem.Type(),
-					)
+					))
 				}
 			}
 		}
 	}
 
-	return nil
+	return validationErrors
 }
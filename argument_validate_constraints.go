@@ -0,0 +1,209 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+// fieldIndex maps the dotted path of every field to the field.
+type fieldIndex map[string]field
+
+// lookup returns the field named by a constraint tag. Names are Go field names relative
+// to the struct of f (e.g. "Cert" next to "TLS.Key"), or dotted paths from the top-level struct.
+func (i fieldIndex) lookup(
+	ctx context.Context,
+	f field,
+	tagName string,
+	name string,
+) (field, error) {
+	if index := strings.LastIndex(f.path, "."); index >= 0 {
+		if other, ok := i[f.path[:index+1]+name]; ok {
+			return other, nil
+		}
+	}
+	if other, ok := i[name]; ok {
+		return other, nil
+	}
+	return field{}, errors.Errorf(
+		ctx,
+		"%s tag of field %s references unknown field %s",
+		tagName,
+		f.path,
+		name,
+	)
+}
+
+// validateConstraints checks the requiredIf, requiredWith and excludes tags of f.
+// requiredIf and requiredWith are skipped for fields with required:"true".
+func validateConstraints(
+	ctx context.Context,
+	f field,
+	fields fieldIndex,
+) (ValidationErrors, error) {
+	var result ValidationErrors
+	tag := f.structField.Tag
+	required := tag.Get("required") == "true"
+	if condition, ok := tag.Lookup("requiredIf"); ok && !required {
+		name, values, found := strings.Cut(condition, "=")
+		if !found {
+			return nil, errors.Errorf(
+				ctx,
+				"requiredIf tag %q of field %s must be Field=value",
+				condition,
+				f.path,
+			)
+		}
+		other, err := fields.lookup(ctx, f, "requiredIf", name)
+		if err != nil {
+			return nil, err
+		}
+		value := fieldString(other)
+		for _, expected := range strings.Split(values, "|") {
+			if value != expected {
+				continue
+			}
+			empty, err := isEmpty(ctx, f)
+			if err != nil {
+				return nil, err
+			}
+			if empty {
+				reason := fmt.Sprintf(
+					"%s, because %s is %q",
+					requiredReason(f),
+					describeField(other),
+					value,
+				)
+				result = append(result, newValidationError(f, reason, ErrRequired))
+			}
+			break
+		}
+	}
+	if names, ok := tag.Lookup("requiredWith"); ok && !required {
+		for _, name := range strings.Split(names, ",") {
+			other, err := fields.lookup(ctx, f, "requiredWith", strings.TrimSpace(name))
+			if err != nil {
+				return nil, err
+			}
+			set, err := isSet(ctx, other)
+			if err != nil {
+				return nil, err
+			}
+			if !set {
+				continue
+			}
+			empty, err := isEmpty(ctx, f)
+			if err != nil {
+				return nil, err
+			}
+			if empty {
+				reason := fmt.Sprintf(
+					"%s, because %s is set",
+					requiredReason(f),
+					describeField(other),
+				)
+				result = append(result, newValidationError(f, reason, ErrRequired))
+			}
+			break
+		}
+	}
+	if names, ok := tag.Lookup("excludes"); ok {
+		set, err := isSet(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range strings.Split(names, ",") {
+			other, err := fields.lookup(ctx, f, "excludes", strings.TrimSpace(name))
+			if err != nil {
+				return nil, err
+			}
+			if !set {
+				continue
+			}
+			otherSet, err := isSet(ctx, other)
+			if err != nil {
+				return nil, err
+			}
+			if otherSet {
+				reason := fmt.Sprintf(
+					"Conflicting fields, %s excludes %s",
+					describeField(f),
+					describeField(other),
+				)
+				result = append(result, newValidationError(f, reason, ErrConflict))
+			}
+		}
+	}
+	return result, nil
+}
+
+// validateExclusiveGroups reports every group of fields with the same exclusive tag
+// that has more than one field set.
+func validateExclusiveGroups(ctx context.Context, fields []field) (ValidationErrors, error) {
+	var groups []string
+	setFields := make(map[string][]field)
+	for _, f := range fields {
+		group, ok := f.structField.Tag.Lookup("exclusive")
+		if !ok {
+			continue
+		}
+		if _, ok := setFields[group]; !ok {
+			groups = append(groups, group)
+			setFields[group] = nil
+		}
+		set, err := isSet(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+		if set {
+			setFields[group] = append(setFields[group], f)
+		}
+	}
+	var result ValidationErrors
+	for _, group := range groups {
+		members := setFields[group]
+		if len(members) < 2 {
+			continue
+		}
+		names := make([]string, len(members))
+		for i, member := range members {
+			names[i] = describeField(member)
+		}
+		reason := fmt.Sprintf(
+			"Conflicting fields, only one of %s may be set (group %s)",
+			strings.Join(names, ", "),
+			group,
+		)
+		result = append(result, newValidationError(members[1], reason, ErrConflict))
+	}
+	return result, nil
+}
+
+// isSet reports whether a field counts as set for constraints. Bools are set if true,
+// all other fields if they are not empty.
+func isSet(ctx context.Context, f field) (bool, error) {
+	if f.value.Kind() == reflect.Bool {
+		return f.value.Bool(), nil
+	}
+	empty, err := isEmpty(ctx, f)
+	if err != nil {
+		return false, err
+	}
+	return !empty, nil
+}
+
+// fieldString returns the value of a field as compared by requiredIf. Nil pointers are "".
+func fieldString(f field) string {
+	value := printValue(f.value)
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(formatValue(value))
+}
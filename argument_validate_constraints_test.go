@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"context"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("Cross-field constraints", func() {
+	type tls struct {
+		Cert string `arg:"cert" env:"CERT"`
+		Key  string `arg:"key"  env:"KEY"  requiredWith:"Cert"`
+	}
+	type config struct {
+		Mode   string `arg:"mode"    env:"MODE"`
+		Token  string `arg:"token"   env:"TOKEN" requiredIf:"Mode=prod|staging"`
+		DryRun bool   `arg:"dry-run"                                            excludes:"Force"`
+		Force  bool   `arg:"force"`
+		JSON   bool   `arg:"json"                                                                exclusive:"output"`
+		YAML   bool   `arg:"yaml"                                                                exclusive:"output"`
+		Text   bool   `arg:"text"                                                                exclusive:"output"`
+		TLS    tls    `arg:"tls-"    env:"TLS_"`
+		CA     string `arg:"ca"                                                                                     requiredWith:"TLS.Cert"`
+	}
+	var ctx context.Context
+	var cfg config
+	BeforeEach(func() {
+		ctx = context.Background()
+		cfg = config{}
+	})
+	It("accepts configs without conflicts", func() {
+		cfg.Mode = "dev"
+		cfg.DryRun = true
+		cfg.JSON = true
+		Expect(argument.ValidateRequired(ctx, &cfg)).To(Succeed())
+	})
+	It("requires fields if another field has a value", func() {
+		cfg.Mode = "staging"
+		err := argument.ValidateRequired(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(
+			`Required field empty, define parameter token or define env TOKEN, because parameter mode or env MODE is "staging"`,
+		))
+		Expect(errors.Is(err, argument.ErrRequired)).To(BeTrue())
+		cfg.Token = "secret"
+		Expect(argument.ValidateRequired(ctx, &cfg)).To(Succeed())
+	})
+	It("requires fields if another field is set", func() {
+		cfg.TLS.Cert = "cert.pem"
+		err := argument.ValidateRequired(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("2 validation errors: "))
+		Expect(err.Error()).To(ContainSubstring(
+			"Required field empty, define parameter tls-key or define env TLS_KEY, because parameter tls-cert or env TLS_CERT is set",
+		))
+		Expect(err.Error()).To(ContainSubstring(
+			"Required field empty, define parameter ca, because parameter tls-cert or env TLS_CERT is set",
+		))
+		var validationErrors argument.ValidationErrors
+		Expect(errors.As(err, &validationErrors)).To(BeTrue())
+		Expect(validationErrors[0].Field).To(Equal("TLS.Key"))
+	})
+	It("reports excluded fields", func() {
+		cfg.DryRun = true
+		cfg.Force = true
+		err := argument.ValidateRequired(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(
+			"Conflicting fields, parameter dry-run excludes parameter force",
+		))
+		Expect(errors.Is(err, argument.ErrConflict)).To(BeTrue())
+	})
+	It("reports exclusive groups with more than one field set", func() {
+		cfg.JSON = true
+		cfg.Text = true
+		err := argument.ValidateRequired(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(
+			"Conflicting fields, only one of parameter json, parameter text may be set (group output)",
+		))
+		var validationErrors argument.ValidationErrors
+		Expect(errors.As(err, &validationErrors)).To(BeTrue())
+		Expect(validationErrors[0].Field).To(Equal("Text"))
+	})
+	It("returns error for unknown fields", func() {
+		invalid := struct {
+			A string `excludes:"Missing"`
+		}{}
+		err := argument.ValidateRequired(ctx, &invalid)
+		Expect(err).To(HaveOccurred())
+		Expect(
+			err.Error(),
+		).To(ContainSubstring("excludes tag of field A references unknown field Missing"))
+	})
+	It("runs after parsing", func() {
+		var parsed config
+		err := newTestParser([]string{"-dry-run", "-force"}, []string{}).Parse(ctx, &parsed)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("parameter dry-run excludes parameter force"))
+	})
+})
@@ -0,0 +1,262 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bborbe/errors"
+)
+
+// validateTags checks the value of a field against its min, max, len, oneof, pattern and
+// nonempty tags. Nil pointers are skipped, oneof and pattern also skip unset zero values like
+// "", so they only apply to optional fields that were set. Tags that do not fit the field type
+// (e.g. min on a time.Time) are returned as error.
+func validateTags(ctx context.Context, f field) (ValidationErrors, error) {
+	value := f.value
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+	var result ValidationErrors
+	invalid := func(format string, args ...interface{}) {
+		reason := fmt.Sprintf(
+			"Invalid field, %s: ",
+			describeField(f),
+		) + fmt.Sprintf(
+			format,
+			args...)
+		result = append(result, newValidationError(f, reason, ErrInvalid))
+	}
+	tag := f.structField.Tag
+	for _, bound := range []struct {
+		name string
+		fail func(cmp int) bool
+		text string
+	}{
+		{name: "min", fail: func(cmp int) bool { return cmp < 0 }, text: "at least"},
+		{name: "max", fail: func(cmp int) bool { return cmp > 0 }, text: "at most"},
+		{name: "len", fail: func(cmp int) bool { return cmp != 0 }, text: "exactly"},
+	} {
+		limit, ok := tag.Lookup(bound.name)
+		if !ok {
+			continue
+		}
+		cmp, subject, got, err := compareBound(ctx, f, value, bound.name, limit)
+		if err != nil {
+			return nil, err
+		}
+		if bound.fail(cmp) {
+			invalid("%s must be %s %s, got %v", subject, bound.text, limit, got)
+		}
+	}
+	if tag.Get("nonempty") == "true" {
+		length, ok := valueLength(value)
+		if !ok {
+			return nil, errors.Errorf(
+				ctx,
+				"nonempty tag of field %s with type %s is unsupported",
+				f.path,
+				value.Type(),
+			)
+		}
+		if length == 0 {
+			invalid("must not be empty")
+		}
+	}
+	if oneof, ok := tag.Lookup("oneof"); ok && !value.IsZero() {
+		allowed := strings.Split(oneof, "|")
+		if err := eachElement(ctx, f, value, "oneof", func(name string, s string) {
+			for _, a := range allowed {
+				if s == a {
+					return
+				}
+			}
+			invalid("%s must be one of %s, got %q", name, oneof, s)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if pattern, ok := tag.Lookup("pattern"); ok && !value.IsZero() {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(
+				ctx,
+				err,
+				"invalid pattern tag %q of field %s",
+				pattern,
+				f.path,
+			)
+		}
+		if err := eachElement(ctx, f, value, "pattern", func(name string, s string) {
+			if !re.MatchString(s) {
+				invalid("%s must match %s, got %q", name, pattern, s)
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// describeField returns the names a user sets the field with, like the message of
+// validateRequiredField (e.g. "parameter port or env PORT").
+func describeField(f field) string {
+	switch {
+	case f.hasArg && f.hasEnv:
+		return fmt.Sprintf("parameter %s or env %s", f.argName, f.envName)
+	case f.hasArg:
+		return fmt.Sprintf("parameter %s", f.argName)
+	case f.hasPos && f.hasEnv:
+		return fmt.Sprintf("argument %s or env %s", f.posName(), f.envName)
+	case f.hasPos:
+		return fmt.Sprintf("argument %s", f.posName())
+	case f.hasEnv:
+		return fmt.Sprintf("env %s", f.envName)
+	}
+	return fmt.Sprintf("field %s", f.path)
+}
+
+// compareBound compares numbers by value and strings, slices and maps by length with limit.
+// len is only supported for lengths.
+// It returns the sign of the comparison, the compared subject ("value" or "length")
+// and the compared value.
+func compareBound(
+	ctx context.Context,
+	f field,
+	value reflect.Value,
+	name string,
+	limit string,
+) (int, string, interface{}, error) {
+	if isNumberKind(value.Kind()) && name != "len" {
+		limitValue, err := convert(ctx, limit, value.Type())
+		if err != nil {
+			return 0, "", nil, errors.Wrapf(
+				ctx,
+				err,
+				"invalid %s tag %q of field %s",
+				name,
+				limit,
+				f.path,
+			)
+		}
+		return compareNumbers(value, limitValue), "value", formatValue(value.Interface()), nil
+	}
+	length, ok := valueLength(value)
+	if !ok {
+		return 0, "", nil, errors.Errorf(
+			ctx,
+			"%s tag of field %s with type %s is unsupported",
+			name,
+			f.path,
+			value.Type(),
+		)
+	}
+	limitLength, err := strconv.Atoi(limit)
+	if err != nil {
+		return 0, "", nil, errors.Wrapf(
+			ctx,
+			err,
+			"invalid %s tag %q of field %s",
+			name,
+			limit,
+			f.path,
+		)
+	}
+	switch {
+	case length < limitLength:
+		return -1, "length", length, nil
+	case length > limitLength:
+		return 1, "length", length, nil
+	}
+	return 0, "length", length, nil
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// isScalarSlice reports whether t is a slice converted from a single value (e.g. net.IP).
+func isScalarSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && isScalarType(t)
+}
+
+// compareNumbers returns -1, 0 or 1 if a is less than, equal to or greater than b.
+// Both must have the same number kind.
+func compareNumbers(a reflect.Value, b reflect.Value) int {
+	switch {
+	case a.CanInt():
+		return compare(a.Int(), b.Int())
+	case a.CanUint():
+		return compare(a.Uint(), b.Uint())
+	}
+	return compare(a.Float(), b.Float())
+}
+
+func compare[T int64 | uint64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// valueLength returns the number of characters of strings and the number of elements of
+// slices and maps.
+func valueLength(value reflect.Value) (int, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(value.String()), true
+	case reflect.Slice, reflect.Map:
+		if isScalarSlice(value.Type()) {
+			return 0, false
+		}
+		return value.Len(), true
+	}
+	return 0, false
+}
+
+// eachElement calls fn with the string form of the value, or of every element of a slice.
+func eachElement(
+	ctx context.Context,
+	f field,
+	value reflect.Value,
+	name string,
+	fn func(name string, s string),
+) error {
+	switch {
+	case value.Kind() == reflect.Slice && !isScalarType(value.Type()):
+		for i := 0; i < value.Len(); i++ {
+			fn(fmt.Sprintf("element %d", i), fmt.Sprint(formatValue(value.Index(i).Interface())))
+		}
+		return nil
+	case value.Kind() == reflect.Map || value.Kind() == reflect.Struct && !isScalarType(value.Type()):
+		return errors.Errorf(
+			ctx,
+			"%s tag of field %s with type %s is unsupported",
+			name,
+			f.path,
+			value.Type(),
+		)
+	}
+	fn("value", fmt.Sprint(formatValue(value.Interface())))
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("Validation tags", func() {
+	type config struct {
+		Port    int               `arg:"port"    env:"PORT"    min:"1"  max:"65535"`
+		Ratio   float32           `arg:"ratio"                 min:"0"  max:"1"`
+		Timeout time.Duration     `arg:"timeout" env:"TIMEOUT" min:"1s" max:"1h"`
+		Stage   string            `arg:"stage"   env:"STAGE"                        oneof:"dev|staging|prod"`
+		Name    string            `                            min:"2"                                       pattern:"^[a-z]+$"`
+		Code    string            `              env:"CODE"                                                                     len:"3"`
+		Brokers []string          `arg:"brokers" env:"BROKERS"          max:"3"                              pattern:":[0-9]+$"         nonempty:"true"`
+		Labels  map[string]string `              env:"LABELS"           max:"2"`
+		Retries *uint8            `arg:"retries"                        max:"5"`
+	}
+	var ctx context.Context
+	var cfg config
+	BeforeEach(func() {
+		ctx = context.Background()
+		cfg = config{
+			Port:    8080,
+			Ratio:   0.5,
+			Timeout: time.Minute,
+			Stage:   "dev",
+			Name:    "ben",
+			Code:    "abc",
+			Brokers: []string{"kafka:9092"},
+		}
+	})
+	It("accepts valid values", func() {
+		Expect(argument.ValidateHasValidation(ctx, &cfg)).To(Succeed())
+	})
+	It("reports values out of range naming flag and env", func() {
+		cfg.Port = 70000
+		err := argument.ValidateHasValidation(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(
+			"Invalid field, parameter port or env PORT: value must be at most 65535, got 70000",
+		))
+		Expect(errors.Is(err, argument.ErrInvalid)).To(BeTrue())
+		var validationErrors argument.ValidationErrors
+		Expect(errors.As(err, &validationErrors)).To(BeTrue())
+		Expect(validationErrors[0].Field).To(Equal("Port"))
+		Expect(validationErrors[0].Arg).To(Equal("port"))
+		Expect(validationErrors[0].Env).To(Equal("PORT"))
+	})
+	It("compares durations and floats with the field type", func() {
+		cfg.Timeout = 500 * time.Millisecond
+		cfg.Ratio = 1.5
+		err := argument.ValidateHasValidation(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"parameter timeout or env TIMEOUT: value must be at least 1s, got 500ms",
+		))
+		Expect(err.Error()).To(ContainSubstring(
+			"parameter ratio: value must be at most 1, got 1.5",
+		))
+	})
+	It("checks oneof, pattern and len", func() {
+		cfg.Stage = "qa"
+		cfg.Name = "Ben"
+		cfg.Code = "abcd"
+		err := argument.ValidateHasValidation(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("3 validation errors: "))
+		Expect(err.Error()).To(ContainSubstring(
+			`parameter stage or env STAGE: value must be one of dev|staging|prod, got "qa"`,
+		))
+		Expect(err.Error()).To(ContainSubstring(
+			`field Name: value must match ^[a-z]+$, got "Ben"`,
+		))
+		Expect(err.Error()).To(ContainSubstring(
+			"env CODE: length must be exactly 3, got 4",
+		))
+	})
+	It("skips oneof and pattern for unset values", func() {
+		var optional struct {
+			Level string `arg:"level" oneof:"debug|info"`
+			Name  string `arg:"name"  pattern:"^[a-z]+$"`
+		}
+		Expect(argument.ValidateHasValidation(ctx, &optional)).To(Succeed())
+		optional.Level = "trace"
+		err := argument.ValidateHasValidation(ctx, &optional)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`must be one of debug|info, got "trace"`))
+	})
+	It("checks slices and maps by length and elements", func() {
+		cfg.Brokers = []string{"a:1", "b", "c:3", "d:4"}
+		cfg.Labels = map[string]string{"a": "1", "b": "2", "c": "3"}
+		err := argument.ValidateHasValidation(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"parameter brokers or env BROKERS: length must be at most 3, got 4",
+		))
+		Expect(err.Error()).To(ContainSubstring(
+			`parameter brokers or env BROKERS: element 1 must match :[0-9]+$, got "b"`,
+		))
+		Expect(err.Error()).To(ContainSubstring(
+			"env LABELS: length must be at most 2, got 3",
+		))
+	})
+	It("reports empty slices with nonempty", func() {
+		cfg.Brokers = nil
+		err := argument.ValidateHasValidation(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(
+			"Invalid field, parameter brokers or env BROKERS: must not be empty",
+		))
+	})
+	It("skips nil pointers and checks set ones", func() {
+		Expect(argument.ValidateHasValidation(ctx, &cfg)).To(Succeed())
+		retries := uint8(6)
+		cfg.Retries = &retries
+		err := argument.ValidateHasValidation(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("value must be at most 5, got 6"))
+	})
+	It("returns error for invalid tags", func() {
+		type invalid struct {
+			Port int `min:"one"`
+		}
+		err := argument.ValidateHasValidation(ctx, &invalid{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`invalid min tag "one" of field Port`))
+		var validationErrors argument.ValidationErrors
+		Expect(errors.As(err, &validationErrors)).To(BeFalse())
+	})
+	It("returns error for tags unsupported by the field type", func() {
+		type invalid struct {
+			Started time.Time `min:"1"`
+		}
+		err := argument.ValidateHasValidation(ctx, &invalid{})
+		Expect(err).To(HaveOccurred())
+		Expect(
+			err.Error(),
+		).To(ContainSubstring("min tag of field Started with type time.Time is unsupported"))
+	})
+	It("runs with Parse", func() {
+		var parsed config
+		parser := newTestParser([]string{"-port=0", "-brokers=kafka:9092"}, []string{"CODE=abc"})
+		err := parser.Parse(ctx, &parsed)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"Invalid field, parameter port or env PORT: value must be at least 1, got 0",
+		))
+	})
+})
@@ -578,3 +578,41 @@ var _ = Describe("Required", func() {
 		})
 	})
 })
+
+var _ = Describe("ValidateRequired nested structs", func() {
+	type database struct {
+		Host string `arg:"host" env:"HOST" required:"true"`
+	}
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+	It("returns error with prefixed names", func() {
+		args := struct {
+			Database database `arg:"db-" env:"DB_"`
+		}{}
+		err := argument.ValidateRequired(ctx, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(
+			err.Error(),
+		).To(Equal("Required field empty, define parameter db-host or define env DB_HOST"))
+	})
+	It("validates fields of nil struct pointers", func() {
+		args := struct {
+			Database *database `arg:"db-"`
+		}{}
+		err := argument.ValidateRequired(ctx, &args)
+		Expect(err).To(HaveOccurred())
+		Expect(
+			err.Error(),
+		).To(Equal("Required field empty, define parameter db-host or define env HOST"))
+	})
+	It("returns no error if nested field is set", func() {
+		args := struct {
+			Database database `arg:"db-" env:"DB_"`
+		}{
+			Database: database{Host: "localhost"},
+		}
+		Expect(argument.ValidateRequired(ctx, &args)).To(Succeed())
+	})
+})
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+// ErrRequired is the cause of every ValidationError reported for an empty required field.
+//
+// Example:
+//
+//	if errors.Is(err, argument.ErrRequired) {
+//	    // at least one required field is missing
+//	}
+var ErrRequired = stderrors.New("required field empty")
+
+// ErrConflict is the cause of every ValidationError reported by the excludes and exclusive tags.
+var ErrConflict = stderrors.New("conflicting fields set")
+
+// ErrInvalid is the cause of every ValidationError reported by the validation tags
+// min, max, len, oneof, pattern and nonempty.
+var ErrInvalid = stderrors.New("invalid field value")
+
+// ValidationError describes a single field that failed validation.
+type ValidationError struct {
+	// Field is the dotted Go field path (e.g. "Kafka.Brokers"). Empty for the top-level struct.
+	Field string
+	// Arg is the command-line argument name of the field. Empty if the field has no arg tag.
+	Arg string
+	// Env is the environment variable name of the field. Empty if the field has no env tag.
+	Env string
+	// Reason is the human-readable description of the failure.
+	Reason string
+	// Err is the cause of the failure, ErrRequired, ErrInvalid, ErrConflict or the error
+	// returned by Validate.
+	Err error
+}
+
+// newValidationError creates a ValidationError with the names of the given field.
+func newValidationError(f field, reason string, err error) *ValidationError {
+	validationError := &ValidationError{
+		Field:  f.path,
+		Reason: reason,
+		Err:    err,
+	}
+	if f.hasArg {
+		validationError.Arg = f.argName
+	}
+	if f.hasEnv {
+		validationError.Env = f.envName
+	}
+	return validationError
+}
+
+// Error returns the reason followed by the cause.
+// The cause is omitted for ErrRequired, ErrInvalid and ErrConflict, because the reason already describes it.
+func (v *ValidationError) Error() string {
+	if v.Err == nil || v.Err == ErrRequired || v.Err == ErrInvalid || v.Err == ErrConflict {
+		return v.Reason
+	}
+	return v.Reason + ": " + v.Err.Error()
+}
+
+// Unwrap returns the cause, so errors.Is and errors.As can inspect it.
+func (v *ValidationError) Unwrap() error {
+	return v.Err
+}
+
+// ValidationErrors collects every validation failure of a struct.
+// ValidateRequired, ValidateHasValidation, Validate and Parse return it instead of stopping
+// at the first failure, so all problems of a configuration are reported at once.
+//
+// Example:
+//
+//	var validationErrors argument.ValidationErrors
+//	if errors.As(err, &validationErrors) {
+//	    for _, validationError := range validationErrors {
+//	        fmt.Println(validationError.Field, validationError.Arg, validationError.Env, validationError)
+//	    }
+//	}
+type ValidationErrors []*ValidationError
+
+// Error renders a single failure like the failure itself and multiple failures in one line.
+func (v ValidationErrors) Error() string {
+	if len(v) == 1 {
+		return v[0].Error()
+	}
+	messages := make([]string, len(v))
+	for i, validationError := range v {
+		messages[i] = validationError.Error()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(v), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the individual failures, so errors.Is and errors.As match each of them.
+func (v ValidationErrors) Unwrap() []error {
+	result := make([]error, len(v))
+	for i, validationError := range v {
+		result[i] = validationError
+	}
+	return result
+}
+
+// errorOrNil returns nil if no failure was collected, so callers never get a non-nil empty error.
+func (v ValidationErrors) errorOrNil() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+// Validate runs ValidateRequired and ValidateHasValidation and returns the failures of both
+// as ValidationErrors. Errors that are not caused by a field value (e.g. an unsupported type)
+// are returned immediately.
+func Validate(ctx context.Context, data interface{}) error {
+	return validate(ctx, data, fieldOptions{})
+}
+
+// validate works like Validate with the field names of WithEnvPrefix and WithNaming.
+func validate(ctx context.Context, data interface{}, options fieldOptions) error {
+	var result ValidationErrors
+	for _, fn := range []func(context.Context, interface{}, fieldOptions) error{
+		validateRequired,
+		validateHasValidation,
+	} {
+		err := fn(ctx, data, options)
+		if err == nil {
+			continue
+		}
+		var validationErrors ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return err
+		}
+		result = append(result, validationErrors...)
+	}
+	return result.errorOrNil()
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/bborbe/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("ValidationErrors", func() {
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+	Context("ValidateRequired", func() {
+		type db struct {
+			Host string `arg:"host" env:"HOST" required:"true"`
+		}
+		type config struct {
+			Username string `arg:"user" env:"USER"     required:"true"`
+			Password string `           env:"PASSWORD" required:"true"`
+			Name     string `arg:"name"                required:"true"`
+			DB       db     `arg:"db-"  env:"DB_"`
+		}
+		It("reports every missing field", func() {
+			cfg := config{Name: "set"}
+			err := argument.ValidateRequired(ctx, &cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(
+				"3 validation errors: " +
+					"Required field empty, define parameter user or define env USER; " +
+					"Required field empty, define env PASSWORD; " +
+					"Required field empty, define parameter db-host or define env DB_HOST",
+			))
+
+			var validationErrors argument.ValidationErrors
+			Expect(errors.As(err, &validationErrors)).To(BeTrue())
+			Expect(validationErrors).To(HaveLen(3))
+			Expect(validationErrors[0].Field).To(Equal("Username"))
+			Expect(validationErrors[0].Arg).To(Equal("user"))
+			Expect(validationErrors[0].Env).To(Equal("USER"))
+			Expect(validationErrors[1].Field).To(Equal("Password"))
+			Expect(validationErrors[1].Arg).To(BeEmpty())
+			Expect(validationErrors[1].Env).To(Equal("PASSWORD"))
+			Expect(validationErrors[2].Field).To(Equal("DB.Host"))
+			Expect(validationErrors[2].Arg).To(Equal("db-host"))
+			Expect(validationErrors[2].Env).To(Equal("DB_HOST"))
+		})
+		It("supports errors.Is with ErrRequired", func() {
+			var cfg config
+			err := argument.ValidateRequired(ctx, &cfg)
+			Expect(errors.Is(err, argument.ErrRequired)).To(BeTrue())
+		})
+		It("supports errors.As for individual entries", func() {
+			var cfg config
+			err := argument.ValidateRequired(ctx, &cfg)
+			var validationError *argument.ValidationError
+			Expect(errors.As(err, &validationError)).To(BeTrue())
+			Expect(validationError.Field).To(Equal("Username"))
+			Expect(validationError.Reason).To(ContainSubstring("Required field empty"))
+		})
+		It("returns nil if all required fields are set", func() {
+			cfg := config{
+				Username: "user",
+				Password: "secret",
+				Name:     "name",
+				DB:       db{Host: "localhost"},
+			}
+			Expect(argument.ValidateRequired(ctx, &cfg)).To(Succeed())
+		})
+	})
+	Context("ValidateHasValidation", func() {
+		type config struct {
+			Port    testPort    `arg:"port"    env:"PORT"`
+			Timeout testTimeout `arg:"timeout" env:"TIMEOUT"`
+			Brokers []testBroker
+		}
+		It("reports every failing validator", func() {
+			cfg := config{
+				Port:    80,
+				Timeout: 0,
+				Brokers: []testBroker{"a", "kafka:9092", "b"},
+			}
+			err := argument.ValidateHasValidation(ctx, &cfg)
+			Expect(err).To(HaveOccurred())
+
+			var validationErrors argument.ValidationErrors
+			Expect(errors.As(err, &validationErrors)).To(BeTrue())
+			Expect(validationErrors).To(HaveLen(4))
+			Expect(validationErrors[0].Field).To(Equal("Port"))
+			Expect(validationErrors[0].Arg).To(Equal("port"))
+			Expect(validationErrors[0].Env).To(Equal("PORT"))
+			Expect(validationErrors[0].Error()).To(ContainSubstring("port must be >= 1024"))
+			Expect(validationErrors[1].Field).To(Equal("Timeout"))
+			Expect(validationErrors[2].Error()).To(ContainSubstring("field Brokers[0]"))
+			Expect(validationErrors[3].Error()).To(ContainSubstring("field Brokers[2]"))
+			Expect(err.Error()).To(HavePrefix("4 validation errors: "))
+		})
+		It("reports top-level and field failures together", func() {
+			cfg := testValidatingConfig{Port: 80}
+			err := argument.ValidateHasValidation(ctx, &cfg)
+			var validationErrors argument.ValidationErrors
+			Expect(errors.As(err, &validationErrors)).To(BeTrue())
+			Expect(validationErrors).To(HaveLen(2))
+			Expect(validationErrors[0].Field).To(BeEmpty())
+			Expect(validationErrors[1].Field).To(Equal("Port"))
+		})
+		It("keeps the cause for errors.Is", func() {
+			cause := stderrors.New("custom failure")
+			cfg := struct {
+				Value failingValidator
+			}{
+				Value: failingValidator{err: cause},
+			}
+			err := argument.ValidateHasValidation(ctx, &cfg)
+			Expect(errors.Is(err, cause)).To(BeTrue())
+			Expect(errors.Is(err, argument.ErrRequired)).To(BeFalse())
+		})
+	})
+	Context("Validate", func() {
+		It("combines required and validator failures", func() {
+			cfg := struct {
+				Name string   `arg:"name" required:"true"`
+				Port testPort `arg:"port"`
+			}{
+				Port: 80,
+			}
+			err := argument.Validate(ctx, &cfg)
+			var validationErrors argument.ValidationErrors
+			Expect(errors.As(err, &validationErrors)).To(BeTrue())
+			Expect(validationErrors).To(HaveLen(2))
+			Expect(validationErrors[0].Field).To(Equal("Name"))
+			Expect(validationErrors[1].Field).To(Equal("Port"))
+		})
+		It("returns nil without failures", func() {
+			cfg := struct {
+				Name string `arg:"name" required:"true"`
+			}{
+				Name: "set",
+			}
+			Expect(argument.Validate(ctx, &cfg)).To(Succeed())
+		})
+	})
+	It("Parse returns all failures at once", func() {
+		parser := newTestParser([]string{"-port=80"}, []string{})
+		var cfg struct {
+			Host string   `arg:"host" env:"HOST" required:"true"`
+			User string   `arg:"user" env:"USER" required:"true"`
+			Port testPort `arg:"port" env:"PORT"`
+		}
+		err := parser.Parse(ctx, &cfg)
+		Expect(err).To(HaveOccurred())
+		var validationErrors argument.ValidationErrors
+		Expect(errors.As(err, &validationErrors)).To(BeTrue())
+		Expect(validationErrors).To(HaveLen(3))
+		Expect(err.Error()).To(ContainSubstring("3 validation errors"))
+	})
+})
+
+type failingValidator struct {
+	err error
+}
+
+func (f failingValidator) Validate(ctx context.Context) error {
+	return f.err
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/bborbe/errors"
+)
+
+// Change is delivered to subscribers of a Watcher after a successful reload
+// that changed at least one field.
+type Change[T any] struct {
+	// Previous is the configuration before the reload.
+	Previous T
+	// Current is the configuration after the reload.
+	Current T
+	// Fields lists every changed field.
+	Fields []FieldChange
+}
+
+// FieldChange is a single changed field of a reload.
+type FieldChange struct {
+	// Name is the dotted Go field path (e.g. "Kafka.Brokers").
+	Name string
+	// Previous and Current are the values like PrintField.Value.
+	// Both are nil for fields with display:"hidden" or display:"length".
+	Previous interface{}
+	Current  interface{}
+	// Masked is true for fields with display:"hidden" or display:"length".
+	Masked bool
+}
+
+// Watcher holds a configuration of type T and reloads it on SIGHUP or config file change.
+// A reload runs defaults, config file, env and the args given at start again, validates the
+// result with ValidateRequired and ValidateHasValidation and replaces the configuration only
+// if it is valid. Invalid reloads keep the previous configuration.
+type Watcher[T any] struct {
+	parser     *parser
+	current    atomic.Pointer[T]
+	mux        sync.Mutex
+	configFile string
+	// configStat is the state of the config file at the last reload.
+	configStat       fileStat
+	subscribers      []func(ctx context.Context, change Change[T])
+	errorSubscribers []func(ctx context.Context, err error)
+}
+
+// NewWatcher parses and validates the initial configuration of type T with a Parser
+// created by NewParser(opts...). T must be a struct type.
+//
+// Example:
+//
+//	watcher, err := argument.NewWatcher[Config](ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	watcher.Subscribe(func(ctx context.Context, change argument.Change[Config]) {
+//	    for _, field := range change.Fields {
+//	        log.Printf("config %s changed", field.Name)
+//	    }
+//	})
+//	go watcher.Run(ctx, 10*time.Second)
+//	config := watcher.Current()
+func NewWatcher[T any](ctx context.Context, opts ...Option) (*Watcher[T], error) {
+	if err := checkStruct[T](ctx); err != nil {
+		return nil, err
+	}
+	w := &Watcher[T]{
+		parser: NewParser(opts...).(*parser),
+	}
+	data, configFile, err := w.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w.configFile = configFile
+	w.configStat = statFile(configFile)
+	w.current.Store(data)
+	return w, nil
+}
+
+// Current returns the latest valid configuration. Callers must not modify
+// slices and maps of the returned value.
+func (w *Watcher[T]) Current() T {
+	return *w.current.Load()
+}
+
+// Subscribe registers fn to be called after every reload that changed the configuration.
+// Subscribers are called one after another and must not call Subscribe or Reload.
+func (w *Watcher[T]) Subscribe(fn func(ctx context.Context, change Change[T])) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// SubscribeErrors registers fn to be called for every reload of Run that was rejected.
+func (w *Watcher[T]) SubscribeErrors(fn func(ctx context.Context, err error)) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.errorSubscribers = append(w.errorSubscribers, fn)
+}
+
+// Reload parses and validates the configuration again. If it is invalid, the previous
+// configuration is kept and the error is returned. Subscribers are called if a field changed.
+func (w *Watcher[T]) Reload(ctx context.Context) error {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	data, configFile, err := w.load(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, "reload config failed")
+	}
+	w.configFile = configFile
+	w.configStat = statFile(configFile)
+	previous := w.current.Load()
+	fields, err := diffFields(previous, data)
+	if err != nil {
+		return errors.Wrap(ctx, err, "diff config failed")
+	}
+	w.current.Store(data)
+	if len(fields) == 0 {
+		return nil
+	}
+	change := Change[T]{
+		Previous: *previous,
+		Current:  *data,
+		Fields:   fields,
+	}
+	for _, subscriber := range w.subscribers {
+		subscriber(ctx, change)
+	}
+	return nil
+}
+
+// Run reloads the configuration on SIGHUP and, if pollInterval is greater than 0,
+// whenever the modification time or size of the config file changes.
+// Rejected reloads are passed to the error subscribers. Run returns when ctx is done.
+func (w *Watcher[T]) Run(ctx context.Context, pollInterval time.Duration) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	var ticks <-chan time.Time
+	if pollInterval > 0 {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+	w.mux.Lock()
+	stat := w.configStat
+	w.mux.Unlock()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-signals:
+		case <-ticks:
+			next := w.statConfigFile()
+			if next == stat {
+				continue
+			}
+			// A rejected file is not reloaded again until it changes
+			stat = next
+		}
+		if err := w.Reload(ctx); err != nil {
+			w.notifyError(ctx, err)
+		}
+	}
+}
+
+// load parses and validates a new T and returns it with the path of the config file used.
+func (w *Watcher[T]) load(ctx context.Context) (*T, string, error) {
+	data := new(T)
+	flagSet := w.parser.flagSet()
+	setUsage(flagSet, data, w.parser.fieldOptions())
+	if _, err := w.parser.parseOnly(ctx, flagSet, data, w.parser.args()); err != nil {
+		return nil, "", errors.Wrap(ctx, err, "parse failed")
+	}
+	if err := w.parser.checkUnknownEnv(ctx, w.parser.environ(), data); err != nil {
+		return nil, "", errors.Wrap(ctx, err, "parse failed")
+	}
+	if err := validateRequired(ctx, data, w.parser.fieldOptions()); err != nil {
+		return nil, "", errors.Wrap(ctx, err, "validate required failed")
+	}
+	if err := validateHasValidation(ctx, data, w.parser.fieldOptions()); err != nil {
+		return nil, "", errors.Wrap(ctx, err, "validate failed")
+	}
+	return data, configFilePath(flagSet, data, w.parser.environ()), nil
+}
+
+func (w *Watcher[T]) notifyError(ctx context.Context, err error) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	for _, subscriber := range w.errorSubscribers {
+		subscriber(ctx, err)
+	}
+}
+
+// fileStat is the part of the config file state compared by polling.
+type fileStat struct {
+	modTime time.Time
+	size    int64
+}
+
+func (w *Watcher[T]) statConfigFile() fileStat {
+	w.mux.Lock()
+	configFile := w.configFile
+	w.mux.Unlock()
+	return statFile(configFile)
+}
+
+// statFile returns the state of the file, or the zero fileStat if it does not exist.
+func statFile(path string) fileStat {
+	if path == "" {
+		return fileStat{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStat{}
+	}
+	return fileStat{modTime: info.ModTime(), size: info.Size()}
+}
+
+// diffFields returns all fields with different values in previous and current.
+func diffFields(previous interface{}, current interface{}) ([]FieldChange, error) {
+	previousValues := make(map[string]reflect.Value)
+	if err := walkFields(previous, func(f field) error {
+		previousValues[f.path] = f.value
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	var result []FieldChange
+	if err := walkFields(current, func(f field) error {
+		previousValue, ok := previousValues[f.path]
+		var before interface{}
+		if ok {
+			before = printValue(previousValue)
+		}
+		after := printValue(f.value)
+		if reflect.DeepEqual(before, after) {
+			return nil
+		}
+		switch f.display() {
+		case "hidden", "length":
+			result = append(result, FieldChange{Name: f.path, Masked: true})
+		default:
+			result = append(result, FieldChange{
+				Name:     f.path,
+				Previous: formatValue(before),
+				Current:  formatValue(after),
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package argument_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/argument/v2"
+)
+
+var _ = Describe("Watcher", func() {
+	type config struct {
+		Host     string        `arg:"host"     file:"host"     required:"true"`
+		Timeout  time.Duration `arg:"timeout"  file:"timeout"  default:"10s"`
+		Password string        `arg:"password" file:"password" display:"length"`
+		Port     int           `arg:"port"     file:"port"`
+	}
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var path string
+	writeConfig := func(content string) {
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+	}
+	newWatcher := func() (*argument.Watcher[config], error) {
+		return argument.NewWatcher[config](
+			ctx,
+			testOptions([]string{"-port=8080"}, []string{"CONFIG_FILE=" + path})...,
+		)
+	}
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		path = filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		writeConfig("host: a.example.com\npassword: old\n")
+	})
+	AfterEach(func() {
+		cancel()
+	})
+	It("parses the initial configuration", func() {
+		watcher, err := newWatcher()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watcher.Current()).To(Equal(config{
+			Host:     "a.example.com",
+			Timeout:  10 * time.Second,
+			Password: "old",
+			Port:     8080,
+		}))
+	})
+	It("returns error for an invalid initial configuration", func() {
+		writeConfig("timeout: 5s\n")
+		_, err := newWatcher()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("validate required failed"))
+	})
+	It("delivers snapshot and field diff on reload", func() {
+		watcher, err := newWatcher()
+		Expect(err).NotTo(HaveOccurred())
+		var changes []argument.Change[config]
+		watcher.Subscribe(func(ctx context.Context, change argument.Change[config]) {
+			changes = append(changes, change)
+		})
+		writeConfig("host: b.example.com\ntimeout: 30s\npassword: new\nport: 9090\n")
+		Expect(watcher.Reload(ctx)).To(Succeed())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Previous.Host).To(Equal("a.example.com"))
+		Expect(changes[0].Current.Host).To(Equal("b.example.com"))
+		Expect(changes[0].Fields).To(Equal([]argument.FieldChange{
+			{Name: "Host", Previous: "a.example.com", Current: "b.example.com"},
+			{Name: "Timeout", Previous: 10 * time.Second, Current: 30 * time.Second},
+			{Name: "Password", Masked: true},
+		}))
+		Expect(watcher.Current().Port).To(Equal(8080))
+	})
+	It("does not notify if nothing changed", func() {
+		watcher, err := newWatcher()
+		Expect(err).NotTo(HaveOccurred())
+		var called bool
+		watcher.Subscribe(func(ctx context.Context, change argument.Change[config]) {
+			called = true
+		})
+		Expect(watcher.Reload(ctx)).To(Succeed())
+		Expect(called).To(BeFalse())
+	})
+	It("keeps the previous configuration on invalid reload", func() {
+		watcher, err := newWatcher()
+		Expect(err).NotTo(HaveOccurred())
+		writeConfig("host: \"\"\n")
+		Expect(watcher.Reload(ctx)).NotTo(Succeed())
+		Expect(watcher.Current().Host).To(Equal("a.example.com"))
+		writeConfig("timeout: banana\nhost: b.example.com\n")
+		Expect(watcher.Reload(ctx)).NotTo(Succeed())
+		Expect(watcher.Current().Host).To(Equal("a.example.com"))
+	})
+	It("reloads on config file change while running", func() {
+		watcher, err := newWatcher()
+		Expect(err).NotTo(HaveOccurred())
+		var mux sync.Mutex
+		var errs []error
+		watcher.SubscribeErrors(func(ctx context.Context, err error) {
+			mux.Lock()
+			defer mux.Unlock()
+			errs = append(errs, err)
+		})
+		done := make(chan error)
+		go func() {
+			done <- watcher.Run(ctx, 10*time.Millisecond)
+		}()
+		writeConfig("host: bad.example.com\ntimeout: banana\n")
+		Eventually(func() int {
+			mux.Lock()
+			defer mux.Unlock()
+			return len(errs)
+		}).Should(BeNumerically(">=", 1))
+		Expect(watcher.Current().Host).To(Equal("a.example.com"))
+		writeConfig("host: c.example.com\n")
+		Eventually(func() string {
+			return watcher.Current().Host
+		}).Should(Equal("c.example.com"))
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	})
+})
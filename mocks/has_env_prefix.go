@@ -0,0 +1,100 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+
+	argument "github.com/bborbe/argument/v2"
+)
+
+type HasEnvPrefix struct {
+	EnvPrefixStub        func() string
+	envPrefixMutex       sync.RWMutex
+	envPrefixArgsForCall []struct {
+	}
+	envPrefixReturns struct {
+		result1 string
+	}
+	envPrefixReturnsOnCall map[int]struct {
+		result1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *HasEnvPrefix) EnvPrefix() string {
+	fake.envPrefixMutex.Lock()
+	ret, specificReturn := fake.envPrefixReturnsOnCall[len(fake.envPrefixArgsForCall)]
+	fake.envPrefixArgsForCall = append(fake.envPrefixArgsForCall, struct {
+	}{})
+	stub := fake.EnvPrefixStub
+	fakeReturns := fake.envPrefixReturns
+	fake.recordInvocation("EnvPrefix", []interface{}{})
+	fake.envPrefixMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *HasEnvPrefix) EnvPrefixCallCount() int {
+	fake.envPrefixMutex.RLock()
+	defer fake.envPrefixMutex.RUnlock()
+	return len(fake.envPrefixArgsForCall)
+}
+
+func (fake *HasEnvPrefix) EnvPrefixCalls(stub func() string) {
+	fake.envPrefixMutex.Lock()
+	defer fake.envPrefixMutex.Unlock()
+	fake.EnvPrefixStub = stub
+}
+
+func (fake *HasEnvPrefix) EnvPrefixReturns(result1 string) {
+	fake.envPrefixMutex.Lock()
+	defer fake.envPrefixMutex.Unlock()
+	fake.EnvPrefixStub = nil
+	fake.envPrefixReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *HasEnvPrefix) EnvPrefixReturnsOnCall(i int, result1 string) {
+	fake.envPrefixMutex.Lock()
+	defer fake.envPrefixMutex.Unlock()
+	fake.EnvPrefixStub = nil
+	if fake.envPrefixReturnsOnCall == nil {
+		fake.envPrefixReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.envPrefixReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *HasEnvPrefix) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *HasEnvPrefix) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ argument.HasEnvPrefix = new(HasEnvPrefix)
@@ -0,0 +1,653 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	argument "github.com/bborbe/argument/v2"
+)
+
+type Parser struct {
+	DispatchStub        func(context.Context, interface{}, ...argument.Command) error
+	dispatchMutex       sync.RWMutex
+	dispatchArgsForCall []struct {
+		arg1 context.Context
+		arg2 interface{}
+		arg3 []argument.Command
+	}
+	dispatchReturns struct {
+		result1 error
+	}
+	dispatchReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ParseStub        func(context.Context, interface{}) error
+	parseMutex       sync.RWMutex
+	parseArgsForCall []struct {
+		arg1 context.Context
+		arg2 interface{}
+	}
+	parseReturns struct {
+		result1 error
+	}
+	parseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ParseAndPrintStub        func(context.Context, interface{}) error
+	parseAndPrintMutex       sync.RWMutex
+	parseAndPrintArgsForCall []struct {
+		arg1 context.Context
+		arg2 interface{}
+	}
+	parseAndPrintReturns struct {
+		result1 error
+	}
+	parseAndPrintReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ParseArgsStub        func(context.Context, interface{}, []string) error
+	parseArgsMutex       sync.RWMutex
+	parseArgsArgsForCall []struct {
+		arg1 context.Context
+		arg2 interface{}
+		arg3 []string
+	}
+	parseArgsReturns struct {
+		result1 error
+	}
+	parseArgsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ParseEnvStub        func(context.Context, interface{}, []string) error
+	parseEnvMutex       sync.RWMutex
+	parseEnvArgsForCall []struct {
+		arg1 context.Context
+		arg2 interface{}
+		arg3 []string
+	}
+	parseEnvReturns struct {
+		result1 error
+	}
+	parseEnvReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ParseFileStub        func(context.Context, interface{}, string) error
+	parseFileMutex       sync.RWMutex
+	parseFileArgsForCall []struct {
+		arg1 context.Context
+		arg2 interface{}
+		arg3 string
+	}
+	parseFileReturns struct {
+		result1 error
+	}
+	parseFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ParseOnlyStub        func(context.Context, interface{}) error
+	parseOnlyMutex       sync.RWMutex
+	parseOnlyArgsForCall []struct {
+		arg1 context.Context
+		arg2 interface{}
+	}
+	parseOnlyReturns struct {
+		result1 error
+	}
+	parseOnlyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ParseWithReportStub        func(context.Context, interface{}) (argument.Provenance, error)
+	parseWithReportMutex       sync.RWMutex
+	parseWithReportArgsForCall []struct {
+		arg1 context.Context
+		arg2 interface{}
+	}
+	parseWithReportReturns struct {
+		result1 argument.Provenance
+		result2 error
+	}
+	parseWithReportReturnsOnCall map[int]struct {
+		result1 argument.Provenance
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Parser) Dispatch(arg1 context.Context, arg2 interface{}, arg3 ...argument.Command) error {
+	fake.dispatchMutex.Lock()
+	ret, specificReturn := fake.dispatchReturnsOnCall[len(fake.dispatchArgsForCall)]
+	fake.dispatchArgsForCall = append(fake.dispatchArgsForCall, struct {
+		arg1 context.Context
+		arg2 interface{}
+		arg3 []argument.Command
+	}{arg1, arg2, arg3})
+	stub := fake.DispatchStub
+	fakeReturns := fake.dispatchReturns
+	fake.recordInvocation("Dispatch", []interface{}{arg1, arg2, arg3})
+	fake.dispatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Parser) DispatchCallCount() int {
+	fake.dispatchMutex.RLock()
+	defer fake.dispatchMutex.RUnlock()
+	return len(fake.dispatchArgsForCall)
+}
+
+func (fake *Parser) DispatchCalls(stub func(context.Context, interface{}, ...argument.Command) error) {
+	fake.dispatchMutex.Lock()
+	defer fake.dispatchMutex.Unlock()
+	fake.DispatchStub = stub
+}
+
+func (fake *Parser) DispatchArgsForCall(i int) (context.Context, interface{}, []argument.Command) {
+	fake.dispatchMutex.RLock()
+	defer fake.dispatchMutex.RUnlock()
+	argsForCall := fake.dispatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Parser) DispatchReturns(result1 error) {
+	fake.dispatchMutex.Lock()
+	defer fake.dispatchMutex.Unlock()
+	fake.DispatchStub = nil
+	fake.dispatchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) DispatchReturnsOnCall(i int, result1 error) {
+	fake.dispatchMutex.Lock()
+	defer fake.dispatchMutex.Unlock()
+	fake.DispatchStub = nil
+	if fake.dispatchReturnsOnCall == nil {
+		fake.dispatchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.dispatchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) Parse(arg1 context.Context, arg2 interface{}) error {
+	fake.parseMutex.Lock()
+	ret, specificReturn := fake.parseReturnsOnCall[len(fake.parseArgsForCall)]
+	fake.parseArgsForCall = append(fake.parseArgsForCall, struct {
+		arg1 context.Context
+		arg2 interface{}
+	}{arg1, arg2})
+	stub := fake.ParseStub
+	fakeReturns := fake.parseReturns
+	fake.recordInvocation("Parse", []interface{}{arg1, arg2})
+	fake.parseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Parser) ParseCallCount() int {
+	fake.parseMutex.RLock()
+	defer fake.parseMutex.RUnlock()
+	return len(fake.parseArgsForCall)
+}
+
+func (fake *Parser) ParseCalls(stub func(context.Context, interface{}) error) {
+	fake.parseMutex.Lock()
+	defer fake.parseMutex.Unlock()
+	fake.ParseStub = stub
+}
+
+func (fake *Parser) ParseArgsForCall(i int) (context.Context, interface{}) {
+	fake.parseMutex.RLock()
+	defer fake.parseMutex.RUnlock()
+	argsForCall := fake.parseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Parser) ParseReturns(result1 error) {
+	fake.parseMutex.Lock()
+	defer fake.parseMutex.Unlock()
+	fake.ParseStub = nil
+	fake.parseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseReturnsOnCall(i int, result1 error) {
+	fake.parseMutex.Lock()
+	defer fake.parseMutex.Unlock()
+	fake.ParseStub = nil
+	if fake.parseReturnsOnCall == nil {
+		fake.parseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.parseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseAndPrint(arg1 context.Context, arg2 interface{}) error {
+	fake.parseAndPrintMutex.Lock()
+	ret, specificReturn := fake.parseAndPrintReturnsOnCall[len(fake.parseAndPrintArgsForCall)]
+	fake.parseAndPrintArgsForCall = append(fake.parseAndPrintArgsForCall, struct {
+		arg1 context.Context
+		arg2 interface{}
+	}{arg1, arg2})
+	stub := fake.ParseAndPrintStub
+	fakeReturns := fake.parseAndPrintReturns
+	fake.recordInvocation("ParseAndPrint", []interface{}{arg1, arg2})
+	fake.parseAndPrintMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Parser) ParseAndPrintCallCount() int {
+	fake.parseAndPrintMutex.RLock()
+	defer fake.parseAndPrintMutex.RUnlock()
+	return len(fake.parseAndPrintArgsForCall)
+}
+
+func (fake *Parser) ParseAndPrintCalls(stub func(context.Context, interface{}) error) {
+	fake.parseAndPrintMutex.Lock()
+	defer fake.parseAndPrintMutex.Unlock()
+	fake.ParseAndPrintStub = stub
+}
+
+func (fake *Parser) ParseAndPrintArgsForCall(i int) (context.Context, interface{}) {
+	fake.parseAndPrintMutex.RLock()
+	defer fake.parseAndPrintMutex.RUnlock()
+	argsForCall := fake.parseAndPrintArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Parser) ParseAndPrintReturns(result1 error) {
+	fake.parseAndPrintMutex.Lock()
+	defer fake.parseAndPrintMutex.Unlock()
+	fake.ParseAndPrintStub = nil
+	fake.parseAndPrintReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseAndPrintReturnsOnCall(i int, result1 error) {
+	fake.parseAndPrintMutex.Lock()
+	defer fake.parseAndPrintMutex.Unlock()
+	fake.ParseAndPrintStub = nil
+	if fake.parseAndPrintReturnsOnCall == nil {
+		fake.parseAndPrintReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.parseAndPrintReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseArgs(arg1 context.Context, arg2 interface{}, arg3 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.parseArgsMutex.Lock()
+	ret, specificReturn := fake.parseArgsReturnsOnCall[len(fake.parseArgsArgsForCall)]
+	fake.parseArgsArgsForCall = append(fake.parseArgsArgsForCall, struct {
+		arg1 context.Context
+		arg2 interface{}
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.ParseArgsStub
+	fakeReturns := fake.parseArgsReturns
+	fake.recordInvocation("ParseArgs", []interface{}{arg1, arg2, arg3Copy})
+	fake.parseArgsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Parser) ParseArgsCallCount() int {
+	fake.parseArgsMutex.RLock()
+	defer fake.parseArgsMutex.RUnlock()
+	return len(fake.parseArgsArgsForCall)
+}
+
+func (fake *Parser) ParseArgsCalls(stub func(context.Context, interface{}, []string) error) {
+	fake.parseArgsMutex.Lock()
+	defer fake.parseArgsMutex.Unlock()
+	fake.ParseArgsStub = stub
+}
+
+func (fake *Parser) ParseArgsArgsForCall(i int) (context.Context, interface{}, []string) {
+	fake.parseArgsMutex.RLock()
+	defer fake.parseArgsMutex.RUnlock()
+	argsForCall := fake.parseArgsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Parser) ParseArgsReturns(result1 error) {
+	fake.parseArgsMutex.Lock()
+	defer fake.parseArgsMutex.Unlock()
+	fake.ParseArgsStub = nil
+	fake.parseArgsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseArgsReturnsOnCall(i int, result1 error) {
+	fake.parseArgsMutex.Lock()
+	defer fake.parseArgsMutex.Unlock()
+	fake.ParseArgsStub = nil
+	if fake.parseArgsReturnsOnCall == nil {
+		fake.parseArgsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.parseArgsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseEnv(arg1 context.Context, arg2 interface{}, arg3 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.parseEnvMutex.Lock()
+	ret, specificReturn := fake.parseEnvReturnsOnCall[len(fake.parseEnvArgsForCall)]
+	fake.parseEnvArgsForCall = append(fake.parseEnvArgsForCall, struct {
+		arg1 context.Context
+		arg2 interface{}
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.ParseEnvStub
+	fakeReturns := fake.parseEnvReturns
+	fake.recordInvocation("ParseEnv", []interface{}{arg1, arg2, arg3Copy})
+	fake.parseEnvMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Parser) ParseEnvCallCount() int {
+	fake.parseEnvMutex.RLock()
+	defer fake.parseEnvMutex.RUnlock()
+	return len(fake.parseEnvArgsForCall)
+}
+
+func (fake *Parser) ParseEnvCalls(stub func(context.Context, interface{}, []string) error) {
+	fake.parseEnvMutex.Lock()
+	defer fake.parseEnvMutex.Unlock()
+	fake.ParseEnvStub = stub
+}
+
+func (fake *Parser) ParseEnvArgsForCall(i int) (context.Context, interface{}, []string) {
+	fake.parseEnvMutex.RLock()
+	defer fake.parseEnvMutex.RUnlock()
+	argsForCall := fake.parseEnvArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Parser) ParseEnvReturns(result1 error) {
+	fake.parseEnvMutex.Lock()
+	defer fake.parseEnvMutex.Unlock()
+	fake.ParseEnvStub = nil
+	fake.parseEnvReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseEnvReturnsOnCall(i int, result1 error) {
+	fake.parseEnvMutex.Lock()
+	defer fake.parseEnvMutex.Unlock()
+	fake.ParseEnvStub = nil
+	if fake.parseEnvReturnsOnCall == nil {
+		fake.parseEnvReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.parseEnvReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseFile(arg1 context.Context, arg2 interface{}, arg3 string) error {
+	fake.parseFileMutex.Lock()
+	ret, specificReturn := fake.parseFileReturnsOnCall[len(fake.parseFileArgsForCall)]
+	fake.parseFileArgsForCall = append(fake.parseFileArgsForCall, struct {
+		arg1 context.Context
+		arg2 interface{}
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ParseFileStub
+	fakeReturns := fake.parseFileReturns
+	fake.recordInvocation("ParseFile", []interface{}{arg1, arg2, arg3})
+	fake.parseFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Parser) ParseFileCallCount() int {
+	fake.parseFileMutex.RLock()
+	defer fake.parseFileMutex.RUnlock()
+	return len(fake.parseFileArgsForCall)
+}
+
+func (fake *Parser) ParseFileCalls(stub func(context.Context, interface{}, string) error) {
+	fake.parseFileMutex.Lock()
+	defer fake.parseFileMutex.Unlock()
+	fake.ParseFileStub = stub
+}
+
+func (fake *Parser) ParseFileArgsForCall(i int) (context.Context, interface{}, string) {
+	fake.parseFileMutex.RLock()
+	defer fake.parseFileMutex.RUnlock()
+	argsForCall := fake.parseFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Parser) ParseFileReturns(result1 error) {
+	fake.parseFileMutex.Lock()
+	defer fake.parseFileMutex.Unlock()
+	fake.ParseFileStub = nil
+	fake.parseFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseFileReturnsOnCall(i int, result1 error) {
+	fake.parseFileMutex.Lock()
+	defer fake.parseFileMutex.Unlock()
+	fake.ParseFileStub = nil
+	if fake.parseFileReturnsOnCall == nil {
+		fake.parseFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.parseFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseOnly(arg1 context.Context, arg2 interface{}) error {
+	fake.parseOnlyMutex.Lock()
+	ret, specificReturn := fake.parseOnlyReturnsOnCall[len(fake.parseOnlyArgsForCall)]
+	fake.parseOnlyArgsForCall = append(fake.parseOnlyArgsForCall, struct {
+		arg1 context.Context
+		arg2 interface{}
+	}{arg1, arg2})
+	stub := fake.ParseOnlyStub
+	fakeReturns := fake.parseOnlyReturns
+	fake.recordInvocation("ParseOnly", []interface{}{arg1, arg2})
+	fake.parseOnlyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Parser) ParseOnlyCallCount() int {
+	fake.parseOnlyMutex.RLock()
+	defer fake.parseOnlyMutex.RUnlock()
+	return len(fake.parseOnlyArgsForCall)
+}
+
+func (fake *Parser) ParseOnlyCalls(stub func(context.Context, interface{}) error) {
+	fake.parseOnlyMutex.Lock()
+	defer fake.parseOnlyMutex.Unlock()
+	fake.ParseOnlyStub = stub
+}
+
+func (fake *Parser) ParseOnlyArgsForCall(i int) (context.Context, interface{}) {
+	fake.parseOnlyMutex.RLock()
+	defer fake.parseOnlyMutex.RUnlock()
+	argsForCall := fake.parseOnlyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Parser) ParseOnlyReturns(result1 error) {
+	fake.parseOnlyMutex.Lock()
+	defer fake.parseOnlyMutex.Unlock()
+	fake.ParseOnlyStub = nil
+	fake.parseOnlyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseOnlyReturnsOnCall(i int, result1 error) {
+	fake.parseOnlyMutex.Lock()
+	defer fake.parseOnlyMutex.Unlock()
+	fake.ParseOnlyStub = nil
+	if fake.parseOnlyReturnsOnCall == nil {
+		fake.parseOnlyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.parseOnlyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Parser) ParseWithReport(arg1 context.Context, arg2 interface{}) (argument.Provenance, error) {
+	fake.parseWithReportMutex.Lock()
+	ret, specificReturn := fake.parseWithReportReturnsOnCall[len(fake.parseWithReportArgsForCall)]
+	fake.parseWithReportArgsForCall = append(fake.parseWithReportArgsForCall, struct {
+		arg1 context.Context
+		arg2 interface{}
+	}{arg1, arg2})
+	stub := fake.ParseWithReportStub
+	fakeReturns := fake.parseWithReportReturns
+	fake.recordInvocation("ParseWithReport", []interface{}{arg1, arg2})
+	fake.parseWithReportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Parser) ParseWithReportCallCount() int {
+	fake.parseWithReportMutex.RLock()
+	defer fake.parseWithReportMutex.RUnlock()
+	return len(fake.parseWithReportArgsForCall)
+}
+
+func (fake *Parser) ParseWithReportCalls(stub func(context.Context, interface{}) (argument.Provenance, error)) {
+	fake.parseWithReportMutex.Lock()
+	defer fake.parseWithReportMutex.Unlock()
+	fake.ParseWithReportStub = stub
+}
+
+func (fake *Parser) ParseWithReportArgsForCall(i int) (context.Context, interface{}) {
+	fake.parseWithReportMutex.RLock()
+	defer fake.parseWithReportMutex.RUnlock()
+	argsForCall := fake.parseWithReportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Parser) ParseWithReportReturns(result1 argument.Provenance, result2 error) {
+	fake.parseWithReportMutex.Lock()
+	defer fake.parseWithReportMutex.Unlock()
+	fake.ParseWithReportStub = nil
+	fake.parseWithReportReturns = struct {
+		result1 argument.Provenance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Parser) ParseWithReportReturnsOnCall(i int, result1 argument.Provenance, result2 error) {
+	fake.parseWithReportMutex.Lock()
+	defer fake.parseWithReportMutex.Unlock()
+	fake.ParseWithReportStub = nil
+	if fake.parseWithReportReturnsOnCall == nil {
+		fake.parseWithReportReturnsOnCall = make(map[int]struct {
+			result1 argument.Provenance
+			result2 error
+		})
+	}
+	fake.parseWithReportReturnsOnCall[i] = struct {
+		result1 argument.Provenance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Parser) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Parser) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ argument.Parser = new(Parser)
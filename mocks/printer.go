@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	argument "github.com/bborbe/argument/v2"
+)
+
+type Printer struct {
+	PrintStub        func(context.Context, []argument.PrintField) error
+	printMutex       sync.RWMutex
+	printArgsForCall []struct {
+		arg1 context.Context
+		arg2 []argument.PrintField
+	}
+	printReturns struct {
+		result1 error
+	}
+	printReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Printer) Print(arg1 context.Context, arg2 []argument.PrintField) error {
+	var arg2Copy []argument.PrintField
+	if arg2 != nil {
+		arg2Copy = make([]argument.PrintField, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.printMutex.Lock()
+	ret, specificReturn := fake.printReturnsOnCall[len(fake.printArgsForCall)]
+	fake.printArgsForCall = append(fake.printArgsForCall, struct {
+		arg1 context.Context
+		arg2 []argument.PrintField
+	}{arg1, arg2Copy})
+	stub := fake.PrintStub
+	fakeReturns := fake.printReturns
+	fake.recordInvocation("Print", []interface{}{arg1, arg2Copy})
+	fake.printMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Printer) PrintCallCount() int {
+	fake.printMutex.RLock()
+	defer fake.printMutex.RUnlock()
+	return len(fake.printArgsForCall)
+}
+
+func (fake *Printer) PrintCalls(stub func(context.Context, []argument.PrintField) error) {
+	fake.printMutex.Lock()
+	defer fake.printMutex.Unlock()
+	fake.PrintStub = stub
+}
+
+func (fake *Printer) PrintArgsForCall(i int) (context.Context, []argument.PrintField) {
+	fake.printMutex.RLock()
+	defer fake.printMutex.RUnlock()
+	argsForCall := fake.printArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Printer) PrintReturns(result1 error) {
+	fake.printMutex.Lock()
+	defer fake.printMutex.Unlock()
+	fake.PrintStub = nil
+	fake.printReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Printer) PrintReturnsOnCall(i int, result1 error) {
+	fake.printMutex.Lock()
+	defer fake.printMutex.Unlock()
+	fake.PrintStub = nil
+	if fake.printReturnsOnCall == nil {
+		fake.printReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.printReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Printer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Printer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ argument.Printer = new(Printer)